@@ -2,6 +2,7 @@ package gsql
 
 import (
 	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/fields"
 	"github.com/donutnomad/gsql/internal/types"
 )
 
@@ -20,6 +21,9 @@ const (
 	PostgresSQL
 )
 
-const (
-	VALUES FunctionName = "VALUES"
-)
+// VALUES 引用插入行中的值，field.Apply(gsql.VALUES) 与 DuplicateUpdate 一样，
+// MySQL 8.0.19+ 输出 `new`.`col`，低版本、MariaDB 以及不在 INSERT 语句中时输出 VALUES(`col`)
+const VALUES = fields.VALUES
+
+// SRIDWGS84 WGS 84 经纬度坐标系
+const SRIDWGS84 = fields.SRIDWGS84
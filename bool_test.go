@@ -0,0 +1,57 @@
+package gsql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+)
+
+func TestBoolField(t *testing.T) {
+	id := gsql.IntFieldOf[int64]("employees", "id")
+	salary := gsql.IntFieldOf[int64]("employees", "salary")
+	isActive := gsql.BoolFieldOf[bool]("employees", "is_active")
+
+	tests := []struct {
+		name   string
+		where  gsql.Expression
+		expect string
+	}{
+		{"直接作为条件", isActive, "WHERE `employees`.`is_active`"},
+		{"取反", isActive.Not(), "WHERE NOT `employees`.`is_active`"},
+		{"IS TRUE", isActive.IsTrue(), "WHERE `employees`.`is_active` IS TRUE"},
+		{"IS FALSE", isActive.IsFalse(), "WHERE `employees`.`is_active` IS FALSE"},
+		{"AND", isActive.And(salary.Gt(5000)), "WHERE (`employees`.`is_active` AND `employees`.`salary` > 5000)"},
+		{"OR", isActive.Not().Or(salary.Gt(5000)), "WHERE (NOT `employees`.`is_active` OR `employees`.`salary` > 5000)"},
+		{"Eq", isActive.Eq(true), "WHERE `employees`.`is_active` = true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := gsql.Select(id).From(gsql.TN("employees")).Where(tt.where).ToSQL()
+			if !strings.Contains(sql, tt.expect) {
+				t.Errorf("期望包含 %s，实际: %s", tt.expect, sql)
+			}
+		})
+	}
+}
+
+func TestBoolFieldAggregate(t *testing.T) {
+	isActive := gsql.BoolFieldOf[bool]("employees", "is_active")
+
+	sql := gsql.Select(
+		isActive.CountIf().As("active_count"),
+		isActive.Sum().As("active_sum"),
+		gsql.COUNT_IF(isActive.IsFalse()).As("inactive_count"),
+	).From(gsql.TN("employees")).ToSQL()
+
+	for _, expect := range []string{
+		"COUNT(CASE WHEN `employees`.`is_active` THEN 1 END) AS `active_count`",
+		"SUM(CASE WHEN `employees`.`is_active` THEN 1 ELSE 0 END) AS `active_sum`",
+		"AS `inactive_count`",
+		"`employees`.`is_active` IS FALSE",
+	} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %s，实际: %s", expect, sql)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package clause
+
+// PrettyBuilder 格式化输出 SQL 的 Builder，见 gsql.PrettySQL
+// 表达式在 Build 时可以通过它换行和缩进，普通的 Builder 不受影响
+type PrettyBuilder interface {
+	Builder
+	// Newline 换行，下一次写入时按当前层级缩进
+	Newline()
+	// Indent 增加一层缩进
+	Indent()
+	// Dedent 减少一层缩进
+	Dedent()
+	// BuildSource 按子句输出 Expr.Source，不支持的类型返回 false
+	BuildSource(source any) bool
+}
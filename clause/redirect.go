@@ -27,6 +27,8 @@ type Expr struct {
 	SQL                string
 	Vars               []any
 	WithoutParentheses bool
+	// Source 生成 SQL 的语句，如子查询的 *gorm.Statement，PrettyBuilder 通过它按子句重新输出
+	Source any
 }
 
 func (expr Expr) Compat() clause.Expr {
@@ -47,6 +49,11 @@ func (expr Expr) IsEmptySQL() bool {
 
 // Build build raw expression
 func (expr Expr) Build(builder Builder) {
+	if expr.Source != nil {
+		if p, ok := builder.(PrettyBuilder); ok && p.BuildSource(expr.Source) {
+			return
+		}
+	}
 	var (
 		afterParenthesis bool
 		idx              int
@@ -116,7 +123,10 @@ type IN = clause.IN
 type Column = clause.Column
 type Clause = clause.Clause
 type Insert = clause.Insert
+type Values = clause.Values
 type OnConflict = clause.OnConflict
+type Returning = clause.Returning
+type Delete = clause.Delete
 type Set = clause.Set
 type Select = clause.Select
 type Interface = clause.Interface
@@ -38,9 +38,13 @@ func (c CTEClause) Build(builder clause.Builder) {
 	}
 
 	// 构建每个 CTE
+	pretty, _ := builder.(clause.PrettyBuilder)
 	for idx, cte := range c.CTEs {
 		if idx > 0 {
 			writer.WriteString(", ")
+			if pretty != nil {
+				pretty.Newline()
+			}
 		}
 
 		// CTE 名称
@@ -2,9 +2,12 @@ package gsql
 
 import (
 	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
 	"github.com/donutnomad/gsql/internal/types"
 )
 
+const fullOuterJoin = "FULL OUTER JOIN"
+
 func LeftJoin(table ITableName) joiner {
 	return joiner{joinType: "LEFT JOIN", table: table}
 }
@@ -26,11 +29,41 @@ func CrossJoin(table ITableName) joiner {
 	return joiner{joinType: "CROSS JOIN", table: table}
 }
 
+// StraightJoin MySQL 的 STRAIGHT_JOIN，左表总是先于右表读取
+func StraightJoin(table ITableName) joiner {
+	return joiner{joinType: "STRAIGHT_JOIN", table: table}
+}
+
+// FullOuterJoin FULL OUTER JOIN
+// PostgreSQL、SQLite 原生输出；MySQL 不支持，整个查询改写为
+// (... LEFT JOIN ...) UNION ALL (... RIGHT JOIN ... WHERE <左侧连接列> IS NULL)，左侧连接列取 ON 条件中第一个不属于被连接表的列
+// 此时 ORDER BY/LIMIT/OFFSET 作用于合并后的结果，排序字段需要是结果集中的列名
+// 一个查询只能有一个 FULL OUTER JOIN，无法改写时执行返回 ErrFullJoinEmulation
+func FullOuterJoin(table ITableName) joiner {
+	return joiner{joinType: fullOuterJoin, table: table}
+}
+
+// NaturalJoin NATURAL JOIN，按两表同名的列连接
+func NaturalJoin(table ITableName) JoinClause {
+	return JoinClause{JoinType: "NATURAL JOIN", Table: table}
+}
+
+// NaturalLeftJoin NATURAL LEFT JOIN
+func NaturalLeftJoin(table ITableName) JoinClause {
+	return JoinClause{JoinType: "NATURAL LEFT JOIN", Table: table}
+}
+
+// NaturalRightJoin NATURAL RIGHT JOIN
+func NaturalRightJoin(table ITableName) JoinClause {
+	return JoinClause{JoinType: "NATURAL RIGHT JOIN", Table: table}
+}
+
 type JoinClause struct {
 	JoinType string
 	Table    ITableName
 	On       Expression
 	hasOn    bool
+	using    []field.IField
 }
 
 type joiner struct {
@@ -47,6 +80,18 @@ func (j joiner) On(expr Expression) JoinClause {
 	}
 }
 
+// Using 使用 USING (col, ...) 连接，两表中需要有同名的列
+// 示例:
+//
+//	gsql.LeftJoin(o).Using(o.UserID) // LEFT JOIN `orders` USING (`user_id`)
+func (j joiner) Using(columns ...field.IField) JoinClause {
+	return JoinClause{
+		JoinType: j.joinType,
+		Table:    j.table,
+		using:    columns,
+	}
+}
+
 func (j joiner) OnEmpty() JoinClause {
 	return JoinClause{
 		JoinType: j.joinType,
@@ -74,6 +119,9 @@ func (j JoinClause) Or(expr Expression) JoinClause {
 
 func (j JoinClause) Build(builder clause.Builder) {
 	writer := &types.SafeWriter{Builder: builder}
+	if p, ok := builder.(clause.PrettyBuilder); ok {
+		p.Newline()
+	}
 
 	writer.WriteString(j.JoinType)
 	writer.WriteByte(' ')
@@ -107,5 +155,14 @@ func (j JoinClause) Build(builder clause.Builder) {
 	if j.hasOn {
 		writer.WriteString(" ON ")
 		writer.AddVar(writer, j.On)
+	} else if len(j.using) > 0 {
+		writer.WriteString(" USING (")
+		for i, col := range j.using {
+			if i > 0 {
+				writer.WriteByte(',')
+			}
+			writer.WriteQuoted(columnName(col))
+		}
+		writer.WriteByte(')')
 	}
 }
@@ -0,0 +1,191 @@
+package gsql_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+)
+
+func TestJoin_StraightNaturalUsing(t *testing.T) {
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+	nt := nftTokenSchemaBase.As("nt")
+
+	sql := gsql.Select(bb.ID).From(bb).
+		Join(
+			gsql.StraightJoin(ror).On(ror.NFTID.EqF(bb.NFTID)),
+			gsql.NaturalLeftJoin(nftTokenSchemaBase),
+			gsql.InnerJoin(nt).Using(nt.NFTID.WithAlias("token_id"), nt.Account),
+		).
+		ToSQL()
+	for _, expect := range []string{
+		"STRAIGHT_JOIN `ror_records` AS `ror` ON `ror`.`nft_id` = `bb`.`nft_id`",
+		"NATURAL LEFT JOIN `nft_tokens`",
+		"INNER JOIN `nft_tokens` AS `nt` USING (`nft_id`,`account`)",
+	} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %q，实际: %s", expect, sql)
+		}
+	}
+}
+
+func TestJoin_FullOuterJoinEmulation(t *testing.T) {
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+
+	query := gsql.Select(bb.ID, ror.Receiver).From(bb).
+		Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+		Where(bb.Account.Eq("0x1")).
+		Order(bb.ID).
+		Limit(10)
+
+	expect := "(SELECT `bb`.`id`, `ror`.`receiver` FROM balance_records AS bb LEFT JOIN `ror_records` AS `ror` ON `ror`.`nft_id` = `bb`.`nft_id` WHERE `bb`.`account` = '0x1')" +
+		" UNION ALL " +
+		"(SELECT `bb`.`id`, `ror`.`receiver` FROM balance_records AS bb RIGHT JOIN `ror_records` AS `ror` ON `ror`.`nft_id` = `bb`.`nft_id` WHERE `bb`.`account` = '0x1' AND `bb`.`nft_id` IS NULL)" +
+		" ORDER BY `id` LIMIT 10"
+	if sql := query.ToSQL(); sql != expect {
+		t.Errorf("\n期望: %s\n实际: %s", expect, sql)
+	}
+
+	db, pool := openCaptureDB(t, "8.0.36")
+	_ = query.Find(db, &[]map[string]any{})
+	_, _ = query.Count(db)
+	if len(pool.sqls) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(pool.sqls))
+	}
+	if !strings.HasSuffix(pool.sqls[0], " ORDER BY `id` LIMIT ?") {
+		t.Errorf("UNION 之后的 ORDER BY 不应带表名，实际: %s", pool.sqls[0])
+	}
+	if !strings.HasPrefix(pool.sqls[0], "(SELECT ") || !strings.Contains(pool.sqls[0], " UNION ALL ") {
+		t.Errorf("Find 应使用改写后的 SQL，实际: %s", pool.sqls[0])
+	}
+	if !strings.HasPrefix(pool.sqls[1], "SELECT COUNT(*) FROM ((SELECT ") || strings.Contains(pool.sqls[1], "LIMIT") {
+		t.Errorf("Count 应统计合并后的结果，实际: %s", pool.sqls[1])
+	}
+}
+
+func TestJoin_FullOuterJoinGroupBy(t *testing.T) {
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+
+	// 两半分别分组会得到拆开的部分聚合结果，无法改写
+	m := gsqltest.New(t)
+	for name, query := range map[string]*gsql.QueryBuilder{
+		"group by": gsql.Select(ror.Receiver, ror.ID.Count().As("n")).From(bb).
+			Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+			GroupBy(ror.Receiver),
+		"having": gsql.Select(ror.Receiver).From(bb).
+			Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+			GroupBy(ror.Receiver).
+			Having(gsql.Expr("COUNT(*) > ?", 1)),
+		"aggregate": gsql.Select(ror.ID.Count().As("n")).From(bb).
+			Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))),
+	} {
+		if sql := query.ToSQL(); strings.Contains(sql, "UNION ALL") || !strings.Contains(sql, "FULL OUTER JOIN") {
+			t.Errorf("%s: unexpected SQL: %s", name, sql)
+		}
+		if err := query.Find(m, &[]map[string]any{}); !errors.Is(err, gsql.ErrFullJoinEmulation) {
+			t.Errorf("%s: expected ErrFullJoinEmulation, got %v", name, err)
+		}
+	}
+	if n := len(m.Statements()); n != 0 {
+		t.Errorf("expected no statements, got %d", n)
+	}
+
+	// 列名与聚合函数同名时不视为聚合
+	query := gsql.Select(gsql.Field("count")).From(bb).
+		Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID)))
+	if sql := query.ToSQL(); !strings.Contains(sql, "UNION ALL") {
+		t.Errorf("unexpected SQL: %s", sql)
+	}
+}
+
+func TestJoin_FullOuterJoinDistinct(t *testing.T) {
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+
+	// 两半分别去重后 UNION ALL 仍有重复行，无法改写
+	m := gsqltest.New(t)
+	query := gsql.Select(ror.Receiver).From(bb).
+		Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+		Distinct()
+	if sql := query.ToSQL(); strings.Contains(sql, "UNION ALL") || !strings.HasPrefix(sql, "SELECT DISTINCT ") {
+		t.Errorf("unexpected SQL: %s", sql)
+	}
+	if err := query.Find(m, &[]map[string]any{}); !errors.Is(err, gsql.ErrFullJoinEmulation) {
+		t.Errorf("find: expected ErrFullJoinEmulation, got %v", err)
+	}
+	if _, err := query.Count(m); !errors.Is(err, gsql.ErrFullJoinEmulation) {
+		t.Errorf("count: expected ErrFullJoinEmulation, got %v", err)
+	}
+	if n := len(m.Statements()); n != 0 {
+		t.Errorf("expected no statements, got %d", n)
+	}
+}
+
+func TestJoin_FullOuterJoinKeepsDuplicates(t *testing.T) {
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+	type row struct {
+		Account  string `gorm:"column:account"`
+		Receiver string `gorm:"column:receiver"`
+	}
+
+	// 左表两行相同的记录匹配同一行右表记录，结果中应保留两行
+	m := gsqltest.New(t)
+	m.ExpectQueryContains("UNION ALL").WillReturnRows(gsqltest.NewRows("account", "receiver").
+		AddRow("0x1", "0x2").
+		AddRow("0x1", "0x2").
+		AddRow(nil, "0x3"))
+
+	var rows []row
+	err := gsql.Select(bb.Account, ror.Receiver).From(bb).
+		Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+		Find(m, &rows)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if len(rows) != 3 || rows[0] != rows[1] {
+		t.Errorf("duplicate rows must be kept, got %+v", rows)
+	}
+	sql := m.SQLs()[0]
+	if strings.Contains(sql, "UNION DISTINCT") || !strings.Contains(sql, "WHERE `bb`.`nft_id` IS NULL") {
+		t.Errorf("unexpected SQL: %s", sql)
+	}
+}
+
+func TestJoin_FullOuterJoinUnsupported(t *testing.T) {
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+	nt := nftTokenSchemaBase.As("nt")
+
+	m := gsqltest.New(t)
+	query := gsql.Select(bb.ID).From(bb).Join(
+		gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID)),
+		gsql.FullOuterJoin(nt).On(nt.NFTID.EqF(bb.NFTID)),
+	)
+	// 无法改写时 ToSQL 原样输出，执行时返回错误而不是 panic
+	if sql := query.ToSQL(); strings.Count(sql, "FULL OUTER JOIN") != 2 {
+		t.Errorf("unexpected SQL: %s", sql)
+	}
+	if err := query.Find(m, &[]map[string]any{}); !errors.Is(err, gsql.ErrFullJoinEmulation) {
+		t.Errorf("find: expected ErrFullJoinEmulation, got %v", err)
+	}
+	if _, err := query.Count(m); !errors.Is(err, gsql.ErrFullJoinEmulation) {
+		t.Errorf("count: expected ErrFullJoinEmulation, got %v", err)
+	}
+	if n := len(m.Statements()); n != 0 {
+		t.Errorf("expected no statements, got %d", n)
+	}
+
+	// UNION 之后的 ORDER BY 不能引用带表名的列，表达式中的列无法改写
+	query = gsql.Select(bb.ID).From(bb).
+		Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+		Order(gsql.Expr("FIELD(?, 1, 2)", bb.ID))
+	if err := query.Find(m, &[]map[string]any{}); !errors.Is(err, gsql.ErrFullJoinEmulation) {
+		t.Errorf("order: expected ErrFullJoinEmulation, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/clauses2"
 	"gorm.io/driver/mysql"
 )
 
@@ -62,7 +63,7 @@ func (jsonSet *JSONSetExpression) Len() int {
 // Build implements clause.Expression
 // support mysql, sqlite and postgres
 func (jsonSet *JSONSetExpression) Build(builder clause.Builder) {
-	if stmt, ok := builder.(*Statement); ok {
+	if stmt, ok := clauses2.StatementOf(builder); ok {
 		switch stmt.Dialector.Name() {
 		case "mysql":
 
@@ -149,7 +150,7 @@ func (jsonSet *JSONSetExpression) Build(builder clause.Builder) {
 type columnExpression string
 
 func (col columnExpression) Build(builder clause.Builder) {
-	if stmt, ok := builder.(*Statement); ok {
+	if stmt, ok := clauses2.StatementOf(builder); ok {
 		switch stmt.Dialector.Name() {
 		case "mysql", "sqlite", "postgres":
 			builder.WriteString(stmt.Quote(string(col)))
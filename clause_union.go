@@ -46,13 +46,20 @@ func (u unionClause) Build(builder clause.Builder) {
 	}
 
 	mainSQL := lo.Ternary(u.Distinct, " UNION DISTINCT ", " UNION ALL ")
+	pretty, _ := builder.(clause.PrettyBuilder)
 
 	for idx, expr := range u.Exprs {
 		writer.WriteByte('(')
 		expr.Build(builder)
 		writer.WriteByte(')')
 		if idx != len(u.Exprs)-1 {
-			writer.WriteString(mainSQL)
+			if pretty != nil {
+				pretty.Newline()
+				writer.WriteString(mainSQL)
+				pretty.Newline()
+			} else {
+				writer.WriteString(mainSQL)
+			}
 		}
 	}
 }
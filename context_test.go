@@ -0,0 +1,104 @@
+package gsql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+)
+
+type ctxKey struct{}
+
+func TestWithContext_Propagates(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	ctx := context.WithValue(context.Background(), ctxKey{}, "trace-1")
+
+	cases := []struct {
+		name string
+		run  func(db gsql.IGormDB)
+	}{
+		{"Find", func(db gsql.IGormDB) {
+			_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).WithContext(ctx).Find(db)
+		}},
+		{"First", func(db gsql.IGormDB) {
+			_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).WithContext(ctx).First(db)
+		}},
+		{"Count", func(db gsql.IGormDB) {
+			_, _ = gsql.Select(table.ID).From(table).WithContext(ctx).Count(db)
+		}},
+		{"Update", func(db gsql.IGormDB) {
+			gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).WithContext(ctx).
+				Update(db, map[string]any{"generation_id": 2})
+		}},
+		{"Delete", func(db gsql.IGormDB) {
+			gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).WithContext(ctx).Delete(db)
+		}},
+		{"InsertValues", func(db gsql.IGormDB) {
+			_, _ = gsql.InsertInto(table).Value(MessageConsumerProgress{ID: 1}).WithContext(ctx).ExecWithResult(db)
+		}},
+		{"InsertDuplicateUpdate", func(db gsql.IGormDB) {
+			_ = gsql.InsertInto(table).Value(MessageConsumerProgress{ID: 1}).DuplicateUpdate(table.GenerationID).WithContext(ctx).Exec(db)
+		}},
+		{"InsertSelect", func(db gsql.IGormDB) {
+			_ = gsql.InsertInto(table).Select(gsql.SelectG[MessageConsumerProgress]().From(table)).WithContext(ctx).Exec(db)
+		}},
+		{"Replace", func(db gsql.IGormDB) {
+			_ = gsql.ReplaceInto(table).Value(MessageConsumerProgress{ID: 1}).WithContext(ctx).Exec(db)
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, pool := openCaptureDB(t, "8.0.36")
+			tc.run(db)
+			if pool.ctx == nil || pool.ctx.Value(ctxKey{}) != "trace-1" {
+				t.Errorf("context 未传递到 ConnPool，实际: %v", pool.ctx)
+			}
+		})
+	}
+}
+
+func TestWithContext_Canceled(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, _ := openCaptureDB(t, "8.0.36")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rows, err := gsql.SelectG[MessageConsumerProgress]().From(table).WithContext(ctx).Find(db)
+	if !errors.Is(err, context.Canceled) || rows != nil {
+		t.Errorf("期望 context.Canceled，实际: %v %v", rows, err)
+	}
+	err = gsql.InsertInto(table).Value(MessageConsumerProgress{ID: 1}).WithContext(ctx).Exec(db)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("期望 context.Canceled，实际: %v", err)
+	}
+}
+
+func TestFind_ReturnsError(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	m := gsqltest.New(t)
+	errConn := errors.New("connection refused")
+
+	// 没有返回任何行时，执行错误也不能被忽略
+	m.ExpectQuery("SELECT * FROM `message_consumer_progress`").WillReturnError(errConn).Times(2)
+	if _, err := gsql.SelectG[MessageConsumerProgress]().From(table).Find(m); !errors.Is(err, errConn) {
+		t.Errorf("QueryBuilderG.Find: 期望返回执行错误，实际: %v", err)
+	}
+	var rows []MessageConsumerProgress
+	if err := gsql.Select().From(table).Find(m, &rows); !errors.Is(err, errConn) {
+		t.Errorf("QueryBuilder.Find: 期望返回执行错误，实际: %v", err)
+	}
+
+	// 没有匹配的行时返回空结果，不返回 ErrRecordNotFound
+	m.ExpectQuery("SELECT * FROM `message_consumer_progress`").WillReturnRows(gsqltest.NewRows("id")).Times(2)
+	if got, err := gsql.SelectG[MessageConsumerProgress]().From(table).Find(m); err != nil || got != nil {
+		t.Errorf("QueryBuilderG.Find: 期望空结果，实际: %v %v", got, err)
+	}
+	if err := gsql.Select().From(table).Find(m, &rows); err != nil || len(rows) != 0 {
+		t.Errorf("QueryBuilder.Find: 期望空结果，实际: %v %v", rows, err)
+	}
+	if err := m.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,97 @@
+package gsql_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/gsqltest"
+	"github.com/samber/mo"
+)
+
+type orderStatus string
+
+const (
+	orderPending orderStatus = "pending"
+	orderPaid    orderStatus = "paid"
+	orderClosed  orderStatus = "closed"
+)
+
+func (orderStatus) Values() []orderStatus {
+	return []orderStatus{orderPending, orderPaid, orderClosed}
+}
+
+func TestEnumField(t *testing.T) {
+	id := gsql.IntFieldOf[int64]("orders", "id")
+	status := gsql.EnumFieldOf[orderStatus]("orders", "status")
+
+	sql := gsql.Select(id, status.Label(map[orderStatus]string{orderPaid: "已支付", orderPending: "待支付"}, "其他").As("label")).
+		From(gsql.TN("orders")).
+		Where(status.In(orderPending, orderPaid), status.Not(orderClosed)).
+		OrderBy(status.DescDecl(), id.Asc()).
+		ToSQL()
+
+	for _, expect := range []string{
+		"CASE `orders`.`status` WHEN 'pending' THEN '待支付' WHEN 'paid' THEN '已支付' ELSE '其他' END AS `label`",
+		"WHERE `orders`.`status` IN ('pending','paid') AND `orders`.`status` != 'closed'",
+		"ORDER BY FIELD(`orders`.`status`, 'pending', 'paid', 'closed') DESC,`orders`.`id`",
+	} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %s，实际: %s", expect, sql)
+		}
+	}
+
+	sql = gsql.Select(gsql.EnumCase(status.WithAlias("s"), map[orderStatus]int{orderClosed: 9}).As("rank")).
+		From(gsql.TN("orders")).
+		ToSQL()
+	if expect := "CASE `orders`.`status` WHEN 'closed' THEN 9 END AS `rank`"; !strings.Contains(sql, expect) {
+		t.Errorf("期望包含 %s，实际: %s", expect, sql)
+	}
+}
+
+func TestEnumFieldSet(t *testing.T) {
+	status := gsql.EnumFieldOf[orderStatus]("orders", "status")
+	id := gsql.IntFieldOf[int64]("orders", "id")
+
+	db, pool := openCaptureDB(t, "8.0.36")
+	ret := gsql.Select().From(gsql.TN("orders")).Where(id.Eq(1)).Update(db, status.Set(orderPaid))
+	if ret.Error != nil {
+		t.Fatalf("update: %v", ret.Error)
+	}
+	if len(pool.sqls) != 1 || !strings.Contains(pool.sqls[0], "SET `status`=?") {
+		t.Errorf("期望 UPDATE ... SET `status`=?，实际: %v", pool.sqls)
+	}
+}
+
+func TestEnumFieldInvalidValue(t *testing.T) {
+	status := gsql.EnumFieldOf[orderStatus]("orders", "status")
+	id := gsql.IntFieldOf[int64]("orders", "id")
+
+	// 无类型的字符串常量可以隐式转换为 orderStatus，拼写错误只能在构造条件时发现
+	m := gsqltest.New(t)
+	for name, cond := range map[string]clause.Expression{
+		"eq":     status.Eq("actve"),
+		"in":     status.In(orderPaid, "pendng"),
+		"not in": status.NotIn("closd"),
+		"eq opt": status.EqOpt(mo.Some[orderStatus]("payed")),
+	} {
+		err := gsql.Select(id).From(gsql.TN("orders")).Where(cond).Find(m, &[]map[string]any{})
+		if !errors.Is(err, gsql.ErrInvalidEnumValue) {
+			t.Errorf("%s: 期望 ErrInvalidEnumValue，实际: %v", name, err)
+		}
+	}
+	ret := gsql.Select().From(gsql.TN("orders")).Where(id.Eq(1)).Update(m, status.Set("payed"))
+	if !errors.Is(ret.Error, gsql.ErrInvalidEnumValue) {
+		t.Errorf("set: 期望 ErrInvalidEnumValue，实际: %v", ret.Error)
+	}
+	if n := len(m.Statements()); n != 0 {
+		t.Errorf("不合法的取值不应发送到数据库，实际执行了 %d 条语句", n)
+	}
+
+	m.ExpectQueryContains("IN").WillReturnRows(gsqltest.NewRows("id"))
+	if err := gsql.Select(id).From(gsql.TN("orders")).Where(status.In(orderPending, orderPaid)).Find(m, &[]map[string]any{}); err != nil {
+		t.Errorf("合法的取值: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package gsql
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/donutnomad/gsql/internal/clauses2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Operation 执行的语句类型
+type Operation string
+
+const (
+	OpSelect  Operation = "select"
+	OpInsert  Operation = "insert"
+	OpUpsert  Operation = "upsert" // INSERT ... ON DUPLICATE KEY UPDATE
+	OpReplace Operation = "replace"
+	OpUpdate  Operation = "update"
+	OpDelete  Operation = "delete"
+)
+
+// ExecInfo 一次 gsql 执行的信息
+// BeforeExec 时只有 Operation、Dialect、Table 和 Begin，其余字段在执行结束后填充
+type ExecInfo struct {
+	Operation    Operation
+	Dialect      string // Dialector.Name()，如 mysql、postgres
+	Table        string // 来自构建器的 FROM 或插入的模型
+	SQL          string // 使用占位符的 SQL，不包含参数值
+	Fingerprint  string // 语句构建时计算的指纹，与构建器的 Fingerprint() 相同
+	Vars         []any
+	RowsAffected int64
+	Err          error
+	Begin        time.Time
+	Duration     time.Duration
+}
+
+// ExecHook 执行钩子，在每次 gsql 执行（查询、插入、更新、删除）前后调用
+// 实现 gorm.Plugin 后通过 db.Use 注册，只对该连接及其会话生效
+// 示例:
+//
+//	db.Use(otelgsql.New())
+type ExecHook interface {
+	// BeforeExec 返回的 context 会用于本次执行，并传给对应的 AfterExec
+	BeforeExec(ctx context.Context, info *ExecInfo) context.Context
+	AfterExec(ctx context.Context, info *ExecInfo)
+}
+
+// execObserver 一次执行对应的钩子调用状态，没有注册钩子时为 nil
+type execObserver struct {
+	hooks  []ExecHook
+	ctxs   []context.Context
+	info   ExecInfo
+	tx     *GormDB
+	logger *execLogger
+}
+
+// execLogger 包装会话的 logger，记录 gorm 回调执行的占位符 SQL 和参数
+// gorm 在执行结束后会清空 Statement.SQL，只能在 Trace 时通过 ParamsFilter 获取
+type execLogger struct {
+	logger.Interface
+	sql     string
+	vars    []any
+	capture bool // 只记录，不调用内层 logger 的 ParamsFilter
+}
+
+func (l *execLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	called := false
+	l.Interface.Trace(ctx, begin, func() (string, int64) {
+		called = true
+		return fc()
+	}, err)
+	if !called { // 内层 logger 没有输出日志时单独调用 fc 记录 SQL
+		l.capture = true
+		_, _ = fc()
+		l.capture = false
+	}
+}
+
+func (l *execLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	l.sql, l.vars = sql, params
+	if filter, ok := l.Interface.(gorm.ParamsFilter); ok && !l.capture {
+		return filter.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, params
+}
+
+// beginExec 在每次执行前调用：开始记录参数对应的列，调用 ExecHook.BeforeExec，
+// 有钩子或日志需要时在构建语句时计算指纹，再按注册的 Commenter 为语句追加注释
+func beginExec(tx *GormDB, op Operation, table string) *execObserver {
+	trackBoundColumns(tx)
+	o := newExecObserver(tx, op, table)
+	if o != nil || clauses2.LookupBoundColumns(tx.Statement) != nil {
+		trackFingerprint(tx, op)
+	}
+	tagStatement(tx, op)
+	return o
+}
+
+// newExecObserver 调用 tx 上注册的 ExecHook.BeforeExec，并将返回的 context 设置到 tx.Statement
+func newExecObserver(tx *GormDB, op Operation, table string) *execObserver {
+	if tx == nil || tx.Config == nil || len(tx.Config.Plugins) == 0 {
+		return nil
+	}
+	var hooks []ExecHook
+	for _, name := range slices.Sorted(maps.Keys(tx.Config.Plugins)) {
+		if h, ok := tx.Config.Plugins[name].(ExecHook); ok {
+			hooks = append(hooks, h)
+		}
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	o := &execObserver{
+		hooks:  hooks,
+		ctxs:   make([]context.Context, len(hooks)),
+		info:   ExecInfo{Operation: op, Table: unquoteTable(table), Begin: time.Now()},
+		tx:     tx,
+		logger: &execLogger{Interface: tx.Config.Logger},
+	}
+	config := *tx.Config
+	config.Logger = o.logger
+	tx.Config = &config
+	if tx.Dialector != nil {
+		o.info.Dialect = tx.Dialector.Name()
+	}
+	ctx := tx.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for i, h := range hooks {
+		ctx = h.BeforeExec(ctx, &o.info)
+		o.ctxs[i] = ctx
+	}
+	tx.Statement.Context = ctx
+	return o
+}
+
+// end 填充执行结果并按注册的相反顺序调用 ExecHook.AfterExec
+func (o *execObserver) end(stmt *Statement, rows int64, err error) {
+	if o == nil {
+		return
+	}
+	o.info.Duration = time.Since(o.info.Begin)
+	o.tx.Config.Logger = o.logger.Interface
+	o.info.SQL, o.info.Vars = o.logger.sql, o.logger.vars
+	if stmt != nil {
+		if o.info.SQL == "" {
+			o.info.SQL, o.info.Vars = stmt.SQL.String(), stmt.Vars
+		}
+		if o.info.Table == "" {
+			o.info.Table = unquoteTable(stmt.Table)
+		}
+		o.info.Fingerprint = fingerprintOf(stmt)
+	}
+	o.info.RowsAffected = rows
+	o.info.Err = err
+	for i := len(o.hooks) - 1; i >= 0; i-- {
+		o.hooks[i].AfterExec(o.ctxs[i], &o.info)
+	}
+}
+
+// unquoteTable 去掉 Statement.Table 中的引号
+func unquoteTable(table string) string {
+	return strings.Trim(table, "`\"")
+}
+
+// endTx 使用 gorm 执行结果调用 end
+func (o *execObserver) endTx(ret *gorm.DB) {
+	if o == nil || ret == nil {
+		return
+	}
+	o.end(ret.Statement, ret.RowsAffected, ret.Error)
+}
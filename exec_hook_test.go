@@ -0,0 +1,70 @@
+package gsql_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"gorm.io/gorm"
+)
+
+type hookKey struct{}
+
+// recordingHook 记录每次执行的 ExecInfo
+type recordingHook struct {
+	infos []gsql.ExecInfo
+}
+
+func (h *recordingHook) Name() string                 { return "test:recording" }
+func (h *recordingHook) Initialize(db *gorm.DB) error { return nil }
+
+func (h *recordingHook) BeforeExec(ctx context.Context, info *gsql.ExecInfo) context.Context {
+	return context.WithValue(ctx, hookKey{}, string(info.Operation))
+}
+
+func (h *recordingHook) AfterExec(ctx context.Context, info *gsql.ExecInfo) {
+	if ctx.Value(hookKey{}) != string(info.Operation) {
+		panic("AfterExec 应收到 BeforeExec 返回的 context")
+	}
+	h.infos = append(h.infos, *info)
+}
+
+func TestExecHook(t *testing.T) {
+	db, pool := openCaptureDB(t, "8.0.36")
+	hook := &recordingHook{}
+	if err := db.Use(hook); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	table := NewMessageConsumerProgressTable()
+	row := MessageConsumerProgress{ID: 1, ConsumerGroup: "g"}
+
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).Find(db)
+	if pool.ctx.Value(hookKey{}) != "select" {
+		t.Error("执行时应使用 BeforeExec 返回的 context")
+	}
+	_ = gsql.InsertInto(table).Value(row).Exec(db)
+	_ = gsql.InsertInto(table).Value(row).DuplicateUpdate(table.GenerationID).Exec(db)
+	_ = gsql.ReplaceInto(table).Value(row).Exec(db)
+	_ = gsql.Select().From(table).Where(table.ID.Eq(1)).Update(db, map[string]any{"generation_id": 2})
+	_ = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).Delete(db)
+
+	expect := []gsql.Operation{gsql.OpSelect, gsql.OpInsert, gsql.OpUpsert, gsql.OpReplace, gsql.OpUpdate, gsql.OpDelete}
+	if len(hook.infos) != len(expect) {
+		t.Fatalf("期望 %d 次执行，实际: %+v", len(expect), hook.infos)
+	}
+	for i, info := range hook.infos {
+		if info.Operation != expect[i] || info.Table != "message_consumer_progress" || info.Dialect != "mysql" {
+			t.Errorf("#%d 期望 %s message_consumer_progress，实际: %s %s %s", i, expect[i], info.Operation, info.Table, info.Dialect)
+		}
+		if info.SQL == "" || strings.Contains(info.SQL, "'g'") {
+			t.Errorf("#%d SQL 应使用占位符，实际: %s", i, info.SQL)
+		}
+	}
+	if hook.infos[0].Err == nil {
+		t.Error("查询失败时 Err 不应为空")
+	}
+	if hook.infos[4].RowsAffected != 1 || !strings.HasPrefix(hook.infos[4].SQL, "UPDATE") {
+		t.Errorf("UPDATE 信息不正确: %+v", hook.infos[4])
+	}
+}
@@ -0,0 +1,361 @@
+package gsql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ExplainFormat Explain 使用的 EXPLAIN 语法，目前只支持 MySQL 8.0
+type ExplainFormat string
+
+const (
+	ExplainJSON    ExplainFormat = "FORMAT=JSON" // 估算的执行计划，包含每个表的访问方式和成本
+	ExplainTree    ExplainFormat = "FORMAT=TREE" // 树形的估算执行计划
+	ExplainAnalyze ExplainFormat = "ANALYZE"     // 实际执行语句，返回树形计划以及实际的行数和耗时
+)
+
+// ExplainPlan 解析后的执行计划
+type ExplainPlan struct {
+	Format ExplainFormat
+	Raw    string  // EXPLAIN 返回的原始内容
+	Cost   float64 // 整个查询的估算成本
+	Tables []ExplainTable
+	// Filesort 是否需要额外排序（Using filesort）
+	Filesort bool
+	// TemporaryTable 是否使用临时表（Using temporary、物化子查询等）
+	TemporaryTable bool
+}
+
+// ExplainTable 执行计划中对一个表的访问，表名为查询中使用的别名
+type ExplainTable struct {
+	Table string
+	// AccessType 访问方式，与 EXPLAIN 的 type 列相同：ALL、index、range、ref、eq_ref、const、fulltext 等
+	AccessType   string
+	PossibleKeys []string
+	Key          string
+	KeyParts     []string
+	Rows         float64 // 每次扫描检查的行数估计
+	Filtered     float64 // 经过条件过滤后剩余行的百分比，只有 JSON 格式提供
+	Cost         float64 // 访问该表的估算成本
+	Condition    string  // 附加在该表上的过滤条件，只有 JSON 格式提供
+
+	// 以下为 EXPLAIN ANALYZE 的实际执行结果
+	ActualRows float64 // 每次循环返回的平均行数
+	ActualTime float64 // 每次循环返回最后一行的平均耗时，单位毫秒
+	Loops      int64
+}
+
+// FullScan 是否为全表扫描
+func (t ExplainTable) FullScan() bool {
+	return t.AccessType == "ALL"
+}
+
+// Table 返回表名或别名为 name 的第一个访问
+func (p *ExplainPlan) Table(name string) (ExplainTable, bool) {
+	for _, t := range p.Tables {
+		if t.Table == name {
+			return t, true
+		}
+	}
+	return ExplainTable{}, false
+}
+
+// UsesIndex 判断是否通过索引 key 访问表 table
+func (p *ExplainPlan) UsesIndex(table, key string) bool {
+	return slices.ContainsFunc(p.Tables, func(t ExplainTable) bool {
+		return t.Table == table && strings.EqualFold(t.Key, key)
+	})
+}
+
+// FullTableScans 返回全表扫描的表
+func (p *ExplainPlan) FullTableScans() []ExplainTable {
+	var ret []ExplainTable
+	for _, t := range p.Tables {
+		if t.FullScan() {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+// HasFullTableScan 是否有表被全表扫描
+func (p *ExplainPlan) HasFullTableScan() bool {
+	return slices.ContainsFunc(p.Tables, ExplainTable.FullScan)
+}
+
+// Explain 对实际执行的查询语句运行 EXPLAIN 并解析结果
+// 示例:
+//
+//	plan, err := gsql.SelectG[User]().From(u).Where(u.Email.Eq("a")).Explain(db, gsql.ExplainJSON)
+//	if !plan.UsesIndex("users", "idx_email") { ... }
+func (b *QueryBuilderG[T]) Explain(db IDB, format ExplainFormat) (*ExplainPlan, error) {
+	tx, cancel := b.buildExec(db)
+	defer cancel()
+	var dest []*T
+	stmt := tx.Session(&gorm.Session{DryRun: true}).Find(&dest)
+	if stmt.Error != nil {
+		return nil, stmt.Error
+	}
+	var raw string
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Raw("EXPLAIN "+string(format)+" "+stmt.Statement.SQL.String(), stmt.Statement.Vars...).
+		Row().
+		Scan(&raw)
+	if err != nil {
+		return nil, b.timeoutError(tx, err)
+	}
+	return ParseExplain(format, raw)
+}
+
+// Explain 见 QueryBuilderG.Explain
+func (b *QueryBuilder) Explain(db IDB, format ExplainFormat) (*ExplainPlan, error) {
+	return b.as().Explain(db, format)
+}
+
+// ParseExplain 按 format 解析 EXPLAIN 的结果
+func ParseExplain(format ExplainFormat, raw string) (*ExplainPlan, error) {
+	var (
+		plan *ExplainPlan
+		err  error
+	)
+	switch format {
+	case ExplainJSON:
+		plan, err = ParseExplainJSON([]byte(raw))
+	case ExplainTree, ExplainAnalyze:
+		plan, err = ParseExplainTree(raw)
+	default:
+		return nil, fmt.Errorf("gsql: unsupported explain format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan.Format = format
+	return plan, nil
+}
+
+// ParseExplainJSON 解析 EXPLAIN FORMAT=JSON 的结果，表按计划中出现的顺序排列
+func ParseExplainJSON(data []byte) (*ExplainPlan, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeOrdered(dec)
+	if err != nil {
+		return nil, fmt.Errorf("gsql: parse explain json: %w", err)
+	}
+	root, ok := v.(jsonObject)
+	if !ok {
+		return nil, fmt.Errorf("gsql: parse explain json: unexpected %T", v)
+	}
+	plan := &ExplainPlan{Format: ExplainJSON, Raw: string(data)}
+	if qb, ok := root.get("query_block").(jsonObject); ok {
+		if cost, ok := qb.get("cost_info").(jsonObject); ok {
+			plan.Cost = jsonFloat(cost.get("query_cost"))
+		}
+	}
+	walkExplainJSON(root, plan)
+	return plan, nil
+}
+
+func walkExplainJSON(v any, plan *ExplainPlan) {
+	switch v := v.(type) {
+	case jsonObject:
+		if jsonBool(v.get("using_filesort")) {
+			plan.Filesort = true
+		}
+		if jsonBool(v.get("using_temporary_table")) {
+			plan.TemporaryTable = true
+		}
+		if name, ok := v.get("table_name").(string); ok && v.get("access_type") != nil {
+			t := ExplainTable{
+				Table:        name,
+				AccessType:   jsonString(v.get("access_type")),
+				PossibleKeys: jsonStrings(v.get("possible_keys")),
+				Key:          jsonString(v.get("key")),
+				KeyParts:     jsonStrings(v.get("used_key_parts")),
+				Rows:         jsonFloat(v.get("rows_examined_per_scan")),
+				Filtered:     jsonFloat(v.get("filtered")),
+				Condition:    jsonString(v.get("attached_condition")),
+			}
+			if cost, ok := v.get("cost_info").(jsonObject); ok {
+				t.Cost = jsonFloat(cost.get("read_cost")) + jsonFloat(cost.get("eval_cost"))
+			}
+			plan.Tables = append(plan.Tables, t)
+		}
+		for _, kv := range v {
+			walkExplainJSON(kv.value, plan)
+		}
+	case []any:
+		for _, item := range v {
+			walkExplainJSON(item, plan)
+		}
+	}
+}
+
+// jsonObject 保留键顺序的 JSON 对象
+type jsonObject []jsonField
+
+type jsonField struct {
+	key   string
+	value any
+}
+
+func (o jsonObject) get(key string) any {
+	for _, f := range o {
+		if f.key == key {
+			return f.value
+		}
+	}
+	return nil
+}
+
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		var obj jsonObject
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, jsonField{key: key.(string), value: value})
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err = dec.Token()
+		return arr, err
+	case nil:
+		return nil, nil
+	}
+	if _, ok := tok.(json.Delim); ok {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return tok, nil
+}
+
+func jsonString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
+
+func jsonStrings(v any) []string {
+	arr, _ := v.([]any)
+	ret := make([]string, 0, len(arr))
+	for _, item := range arr {
+		ret = append(ret, jsonString(item))
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
+
+// jsonFloat MySQL 的成本和百分比以字符串输出，如 "cost_info": {"query_cost": "1.20"}
+func jsonFloat(v any) float64 {
+	f, _ := strconv.ParseFloat(jsonString(v), 64)
+	return f
+}
+
+func jsonBool(v any) bool {
+	b, _ := v.(bool)
+	return b
+}
+
+// explainAccess EXPLAIN ANALYZE / FORMAT=TREE 中访问表的节点，按匹配顺序排列
+var explainAccess = []struct {
+	re         *regexp.Regexp
+	accessType string
+}{
+	{regexp.MustCompile(`^Table scan on (\S+)`), "ALL"},
+	{regexp.MustCompile(`^Constant row from (\S+)`), "const"},
+	{regexp.MustCompile(`^Single-row (?:covering )?index lookup on (\S+) using (\S+)`), "eq_ref"},
+	{regexp.MustCompile(`^(?:Covering )?[Ii]ndex range scan on (\S+) using (\S+)`), "range"},
+	{regexp.MustCompile(`^Index skip scan on (\S+) using (\S+)`), "range"},
+	{regexp.MustCompile(`^(?:Covering )?[Ii]ndex lookup on (\S+) using (\S+)`), "ref"},
+	{regexp.MustCompile(`^Index scan on (\S+) using (\S+)`), "index"},
+	{regexp.MustCompile(`^Full-text index search on (\S+) using (\S+)`), "fulltext"},
+}
+
+var (
+	explainCost   = regexp.MustCompile(`\(cost=(?:[\d.e+-]+\.\.)?([\d.e+-]+) rows=([\d.e+-]+)\)`)
+	explainActual = regexp.MustCompile(`\(actual time=[\d.e+-]+\.\.([\d.e+-]+) rows=([\d.e+-]+) loops=(\d+)\)`)
+)
+
+// ParseExplainTree 解析 EXPLAIN ANALYZE 或 EXPLAIN FORMAT=TREE 的结果
+func ParseExplainTree(text string) (*ExplainPlan, error) {
+	plan := &ExplainPlan{Format: ExplainTree, Raw: text}
+	first := true
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		node, ok := strings.CutPrefix(line, "-> ")
+		if !ok {
+			continue
+		}
+		cost := explainCost.FindStringSubmatch(node)
+		if first {
+			first = false
+			if cost != nil {
+				plan.Cost, _ = strconv.ParseFloat(cost[1], 64)
+			}
+		}
+		switch {
+		case strings.HasPrefix(node, "Sort"):
+			plan.Filesort = true
+		case strings.HasPrefix(node, "Materialize"), strings.Contains(node, "emporary table"):
+			plan.TemporaryTable = true
+		}
+		for _, a := range explainAccess {
+			m := a.re.FindStringSubmatch(node)
+			if m == nil {
+				continue
+			}
+			t := ExplainTable{Table: m[1], AccessType: a.accessType}
+			if len(m) > 2 {
+				t.Key = m[2]
+			}
+			if cost != nil {
+				t.Cost, _ = strconv.ParseFloat(cost[1], 64)
+				t.Rows, _ = strconv.ParseFloat(cost[2], 64)
+			}
+			if actual := explainActual.FindStringSubmatch(node); actual != nil {
+				t.ActualTime, _ = strconv.ParseFloat(actual[1], 64)
+				t.ActualRows, _ = strconv.ParseFloat(actual[2], 64)
+				t.Loops, _ = strconv.ParseInt(actual[3], 10, 64)
+			}
+			plan.Tables = append(plan.Tables, t)
+			break
+		}
+	}
+	if first {
+		return nil, fmt.Errorf("gsql: parse explain tree: no plan nodes in %q", text)
+	}
+	return plan, nil
+}
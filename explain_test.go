@@ -0,0 +1,90 @@
+package gsql_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+)
+
+func TestParseExplainJSON(t *testing.T) {
+	data, err := os.ReadFile("testdata/explain_join.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := gsql.ParseExplainJSON(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if plan.Cost != 12.85 || !plan.Filesort || !plan.TemporaryTable {
+		t.Errorf("unexpected plan: cost=%v filesort=%v temporary=%v", plan.Cost, plan.Filesort, plan.TemporaryTable)
+	}
+	if len(plan.Tables) != 2 || plan.Tables[0].Table != "u" || plan.Tables[1].Table != "o" {
+		t.Fatalf("unexpected tables: %+v", plan.Tables)
+	}
+	u := plan.Tables[0]
+	if !u.FullScan() || u.Rows != 20 || u.Filtered != 10 || u.Key != "" || u.Condition != "(`test`.`u`.`status` = 1)" {
+		t.Errorf("unexpected table u: %+v", u)
+	}
+	if scans := plan.FullTableScans(); len(scans) != 1 || scans[0].Table != "u" {
+		t.Errorf("unexpected full table scans: %+v", scans)
+	}
+
+	o, ok := plan.Table("o")
+	if !ok || o.AccessType != "ref" || o.Cost != 10.6 || len(o.KeyParts) != 1 || o.KeyParts[0] != "user_id" {
+		t.Errorf("unexpected table o: %+v", o)
+	}
+	if !plan.UsesIndex("o", "idx_user_id") || plan.UsesIndex("u", "PRIMARY") {
+		t.Error("unexpected UsesIndex result")
+	}
+}
+
+func TestParseExplainTree(t *testing.T) {
+	data, err := os.ReadFile("testdata/explain_analyze.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := gsql.ParseExplain(gsql.ExplainAnalyze, string(data))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if plan.Format != gsql.ExplainAnalyze || plan.Cost != 12.85 || !plan.Filesort || plan.TemporaryTable {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	if !plan.HasFullTableScan() || !plan.UsesIndex("o", "idx_user_id") {
+		t.Errorf("unexpected tables: %+v", plan.Tables)
+	}
+	o, _ := plan.Table("o")
+	if o.AccessType != "ref" || o.Rows != 5 || o.ActualRows != 2.67 || o.Loops != 3 || o.ActualTime != 0.095 {
+		t.Errorf("unexpected table o: %+v", o)
+	}
+
+	if _, err := gsql.ParseExplainTree("EXPLAIN"); err == nil {
+		t.Error("expected error for empty plan")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	data, err := os.ReadFile("testdata/explain_join.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := gsql.IntFieldOf[int64]("users", "id")
+	status := gsql.IntFieldOf[int]("users", "status")
+	q := gsql.SelectG[struct{ ID int64 }]().From(gsql.TN("users")).Where(status.Eq(1)).Order(id, true)
+
+	m := gsqltest.New(t)
+	m.ExpectQuery("EXPLAIN FORMAT=JSON SELECT * FROM `users` WHERE `users`.`status` = ? ORDER BY `users`.`id`").
+		WithArgs(1).
+		WillReturnRows(gsqltest.NewRows("EXPLAIN").AddRow(string(data)))
+
+	plan, err := q.Explain(m, gsql.ExplainJSON)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	if plan.Format != gsql.ExplainJSON || !strings.Contains(plan.Raw, "query_block") || len(plan.Tables) != 2 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+}
@@ -12,6 +12,7 @@ const (
 	FlagUniqueIndex   = types.FlagUniqueIndex
 	FlagIndex         = types.FlagIndex
 	FlagAutoIncrement = types.FlagAutoIncrement
+	FlagSensitive     = types.FlagSensitive
 )
 
 type (
@@ -19,6 +20,7 @@ type (
 	Range[T any] = types.Range[T]
 	IToExpr      = fieldi.IToExpr
 	IField       = fieldi.IField
+	ITypedField  = fieldi.ITypedField
 	ExpressionTo = fieldi.ExpressionTo
 	BaseFields   = fields.BaseFields
 )
@@ -0,0 +1,548 @@
+package gsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/donutnomad/gsql/clause"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	gormclause "gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// Fingerprint 返回表达式的指纹，用于按语句结构分组统计
+// 参数值不参与指纹，参数列表只保留一个占位符，如 IN (?,?,?) 与 IN (?,?) 的指纹相同
+// 示例:
+//
+//	gsql.Fingerprint(t.ID.In(1, 2, 3)) // `t`.`id` IN (?)
+func Fingerprint(expr clause.Expression) string {
+	if expr == nil {
+		return ""
+	}
+	tx := newExprDB()
+	expr.Build(&fingerprintBuilder{Statement: tx.Statement})
+	return normalizeSQL(tx.Statement.SQL.String(), false)
+}
+
+// Fingerprint 返回查询语句的指纹，见 gsql.Fingerprint
+func (b *QueryBuilderG[T]) Fingerprint() string {
+	if b.emulateFullJoin(dialector) {
+		return Fingerprint(fullJoinUnion[T]{b})
+	}
+	tx := newExprDB()
+	b.buildStmt(tx.Statement, getQuoteFunc())
+	callbacks.BuildQuerySQL(tx)
+	return stmtFingerprint(tx.Statement, queryClauses)
+}
+
+// Fingerprint 返回查询语句的指纹，见 gsql.Fingerprint
+func (b *QueryBuilder) Fingerprint() string {
+	return b.as().Fingerprint()
+}
+
+// Fingerprint 返回插入语句的指纹，多行 VALUES 只保留一行，见 gsql.Fingerprint
+// 使用 MySQL 8.0 的语法生成，不会修改要插入的值，也不会调用模型的钩子方法
+func (b *insertBuilderWithValues[T]) Fingerprint() string {
+	if b.values == nil || len(*b.values) == 0 {
+		return ""
+	}
+	stmt, err := b.dryRun(&[]T{(*b.values)[0]})
+	if err != nil {
+		return ""
+	}
+	return stmtFingerprint(stmt, nil)
+}
+
+// dryRun 在 dryRunDB 上构建插入 rows 的语句，与 Exec 构建的语句相同
+func (b *insertBuilderWithValues[T]) dryRun(rows *[]T) (*Statement, error) {
+	tx, err := b.newTx(dryRunDB())
+	if err != nil {
+		return nil, err
+	}
+	if len(b.duplicateUpdates) > 0 {
+		if tx, err = b.buildDirect(tx, rows, ""); err != nil {
+			return nil, err
+		}
+		return tx.Statement, nil
+	}
+	ret := tx.Create(rows)
+	if ret.Error != nil {
+		return nil, ret.Error
+	}
+	// 执行结束后 BuildClauses 会被重置，使用 Create 回调的子句
+	ret.Statement.BuildClauses = ret.Callback().Create().Clauses
+	return ret.Statement, nil
+}
+
+// Fingerprint 返回插入语句的指纹，见 gsql.Fingerprint
+func (b *insertBuilderWithSelect[T]) Fingerprint() string {
+	tx, _, err := b.build(dryRunDB())
+	if err != nil {
+		return ""
+	}
+	return stmtFingerprint(tx.Statement, nil)
+}
+
+// Fingerprint 返回语句的指纹，见 gsql.Fingerprint
+func (r *replaceBuilderWithValues[T]) Fingerprint() string {
+	return r.b.Fingerprint()
+}
+
+// Fingerprint 返回语句的指纹，见 gsql.Fingerprint
+func (r *replaceBuilderWithSelect[T]) Fingerprint() string {
+	return r.b.Fingerprint()
+}
+
+// dryRunDB 生成插入语句的指纹和格式化 SQL 使用的 DryRun 连接，不会连接数据库
+var dryRunDB = sync.OnceValue(func() *GormDB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      dryRunPool{},
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return db.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+})
+
+// dryRunPool dryRunDB 的连接，DryRun 模式下不会被调用
+type dryRunPool struct{}
+
+func (dryRunPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, gorm.ErrInvalidDB
+}
+
+func (dryRunPool) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, gorm.ErrInvalidDB
+}
+
+func (dryRunPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, gorm.ErrInvalidDB
+}
+
+func (dryRunPool) QueryRowContext(context.Context, string, ...any) *sql.Row {
+	return nil
+}
+
+// fingerprintKey 语句构建时计算的指纹在 Statement.Settings 中的键
+const fingerprintKey = "gsql:fingerprint"
+
+// builtFingerprint 语句构建时计算的指纹
+// 只对计算时的 Statement 有效，会话复制 Settings 时不会带给其他语句
+type builtFingerprint struct {
+	stmt        *Statement
+	sql         string // 计算时已构建的 SQL，已构建的语句（如 Raw）复制到新会话时按 SQL 沿用
+	fingerprint string
+}
+
+// setFingerprint 保存 stmt 构建时的指纹
+func setFingerprint(stmt *Statement, fingerprint string) {
+	stmt.Settings.Store(fingerprintKey, builtFingerprint{stmt: stmt, sql: stmt.SQL.String(), fingerprint: fingerprint})
+}
+
+// fingerprintOf 返回 stmt 构建时保存的指纹，没有保存时返回空字符串
+func fingerprintOf(stmt *Statement) string {
+	if v, ok := stmt.Settings.Load(fingerprintKey); ok {
+		if f, ok := v.(builtFingerprint); ok && f.stmt == stmt {
+			return f.fingerprint
+		}
+	}
+	return ""
+}
+
+// trackFingerprint 在语句构建时计算指纹并保存到 Statement
+// 已构建的语句（如 ON DUPLICATE KEY UPDATE 插入、Raw）沿用构建时保存的指纹，没有时按子句计算；
+// 由 gorm 回调构建的语句在最后一个子句构建完成后计算，此时 Count 等临时修改的子句还没有恢复
+func trackFingerprint(tx *GormDB, op Operation) {
+	stmt := tx.Statement
+	if stmt.SQL.Len() > 0 {
+		if v, ok := stmt.Settings.Load(fingerprintKey); ok {
+			if f, ok := v.(builtFingerprint); ok && (f.stmt == stmt || f.sql == stmt.SQL.String()) {
+				setFingerprint(stmt, f.fingerprint)
+				return
+			}
+		}
+		setFingerprint(stmt, stmtFingerprint(stmt, nil))
+		return
+	}
+
+	config := *tx.Config
+	config.ClauseBuilders = maps.Clone(config.ClauseBuilders)
+	if config.ClauseBuilders == nil {
+		config.ClauseBuilders = map[string]clause.ClauseBuilder{}
+	}
+	for _, name := range operationClauses(tx, op) {
+		prev := config.ClauseBuilders[name]
+		config.ClauseBuilders[name] = func(c clause.Clause, builder clause.Builder) {
+			if prev != nil {
+				prev(c, builder)
+			} else {
+				c.Build(builder)
+			}
+			if stmt, ok := builder.(*Statement); ok && isLastClause(stmt, name) {
+				setFingerprint(stmt, stmtFingerprint(stmt, nil))
+			}
+		}
+	}
+	tx.Config = &config
+}
+
+// isLastClause 判断 name 是否是 stmt 要构建的最后一个子句，不包括 SQL 注释
+func isLastClause(stmt *Statement, name string) bool {
+	i := slices.Index(stmt.BuildClauses, name)
+	if i < 0 {
+		return false
+	}
+	for _, later := range stmt.BuildClauses[i+1:] {
+		if _, ok := stmt.Clauses[later]; ok && later != commentClauseName {
+			return false
+		}
+	}
+	return true
+}
+
+// stmtFingerprint 使用 fingerprintBuilder 重新构建 stmt 中的子句，clauses 为空时使用 stmt.BuildClauses
+func stmtFingerprint(stmt *Statement, clauses []string) string {
+	if len(clauses) == 0 {
+		clauses = stmt.BuildClauses
+	}
+	return normalizeSQL(buildFingerprint(stmt, clauses), false)
+}
+
+// buildFingerprint 使用 fingerprintBuilder 构建 stmt 中的 clauses，多行 VALUES 只保留一行
+func buildFingerprint(stmt *Statement, clauses []string) string {
+	fs := &Statement{
+		DB:        stmt.DB,
+		Table:     stmt.Table,
+		TableExpr: stmt.TableExpr,
+		Schema:    stmt.Schema,
+		Clauses:   stmt.Clauses,
+		Context:   stmt.Context,
+	}
+	stmt.Settings.Range(func(k, v any) bool {
+		fs.Settings.Store(k, v)
+		return true
+	})
+	b := &fingerprintBuilder{Statement: fs}
+	first := true
+	for _, name := range clauses {
+		c, ok := stmt.Clauses[name]
+		if !ok || name == commentClauseName {
+			continue
+		}
+		if !first {
+			_ = b.WriteByte(' ')
+		}
+		first = false
+		build, custom := stmt.DB.ClauseBuilders[name]
+		switch v := c.Expression.(type) {
+		case clause.Values:
+			if len(v.Values) > 1 {
+				v.Values = v.Values[:1]
+				c.Expression = v
+			}
+		case clause.OnConflict:
+			// MySQL 的构建器在 DoUpdates 为空时会将 builder 断言为 Statement，这里先按相同的方式填充主键
+			if len(v.DoUpdates) == 0 {
+				if column := primaryColumn(stmt.Schema); column.Name != "" {
+					v.DoNothing = false
+					v.DoUpdates = []gormclause.Assignment{{Column: column, Value: column}}
+					c.Expression = v
+				} else {
+					custom = false
+				}
+			}
+		}
+		if custom {
+			build(c, b)
+		} else {
+			c.Build(b)
+		}
+	}
+	return fs.SQL.String()
+}
+
+// primaryColumn 返回 MySQL 空的 ON DUPLICATE KEY UPDATE 使用的列
+func primaryColumn(s *schema.Schema) clause.Column {
+	switch {
+	case s == nil:
+	case s.PrioritizedPrimaryField != nil:
+		return clause.Column{Name: s.PrioritizedPrimaryField.DBName}
+	case len(s.DBNames) > 0:
+		return clause.Column{Name: s.DBNames[0]}
+	}
+	return clause.Column{}
+}
+
+// fingerprintBuilder 与 Statement 相同，但参数只输出占位符
+// 只有切片参数展开的值列表（如 IN 列表）中相同的元素合并为一个，其他相邻的参数（如 LIMIT ?,?）保持不变
+type fingerprintBuilder struct {
+	*Statement
+}
+
+// GormStatement 供需要读取 Statement 的表达式使用，见 clauses2.StatementOf
+func (b *fingerprintBuilder) GormStatement() *Statement {
+	return b.Statement
+}
+
+// fingerprintBuilder 实现 clause.PrettyBuilder，子查询按 Expr.Source 记录的语句重新构建，
+// 而不是使用已经展开值列表的 SQL；换行和缩进不输出
+var _ clause.PrettyBuilder = (*fingerprintBuilder)(nil)
+
+func (b *fingerprintBuilder) Newline() {}
+
+func (b *fingerprintBuilder) Indent() {}
+
+func (b *fingerprintBuilder) Dedent() {}
+
+// BuildSource 按子句重新构建子查询的语句
+func (b *fingerprintBuilder) BuildSource(source any) bool {
+	stmt, ok := source.(*Statement)
+	if !ok {
+		return false
+	}
+	clauses := stmt.BuildClauses
+	if len(clauses) == 0 {
+		clauses = queryClauses
+	}
+	_, _ = b.WriteString(buildFingerprint(stmt, clauses))
+	return true
+}
+
+func (b *fingerprintBuilder) AddVar(writer clause.Writer, vars ...any) {
+	for i, v := range vars {
+		if i > 0 {
+			_ = writer.WriteByte(',')
+		}
+		b.addVar(writer, v)
+	}
+}
+
+func (b *fingerprintBuilder) addVar(writer clause.Writer, v any) {
+	switch v := v.(type) {
+	case sql.NamedArg:
+	case clause.Column, clause.Table:
+		b.QuoteTo(writer, v)
+	case gorm.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			_ = writer.WriteByte('?')
+			return
+		}
+		ctx := b.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		b.addVar(writer, v.GormValue(ctx, b.DB))
+	case clause.Interface:
+		c := clause.Clause{Name: v.Name()}
+		v.MergeClause(&c)
+		c.Build(b)
+	case clause.Expression:
+		v.Build(b)
+	case driver.Valuer, []byte:
+		_ = writer.WriteByte('?')
+	case []any:
+		b.addList(writer, v)
+	default:
+		rv := reflect.ValueOf(v)
+		if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() != reflect.Uint8 {
+			list := make([]any, rv.Len())
+			for i := range list {
+				list[i] = rv.Index(i).Interface()
+			}
+			b.addList(writer, list)
+			return
+		}
+		_ = writer.WriteByte('?')
+	}
+}
+
+// addList 输出值列表，与前一个元素输出相同的元素不再输出，如 IN (?,?,?) 输出为 IN (?)
+func (b *fingerprintBuilder) addList(writer clause.Writer, list []any) {
+	if len(list) == 0 {
+		_, _ = writer.WriteString("(NULL)")
+		return
+	}
+	_ = writer.WriteByte('(')
+	if writer != clause.Writer(b) {
+		b.AddVar(writer, list...)
+		_ = writer.WriteByte(')')
+		return
+	}
+	var prev string
+	for i, v := range list {
+		start := b.SQL.Len()
+		if i > 0 {
+			_ = b.WriteByte(',')
+		}
+		b.addVar(b, v)
+		s := b.SQL.String()
+		item := s[start:]
+		if i > 0 {
+			item = item[1:]
+			if item == prev {
+				b.SQL.Reset()
+				b.SQL.WriteString(s[:start])
+				continue
+			}
+		}
+		prev = item
+	}
+	_ = b.WriteByte(')')
+}
+
+// FingerprintSQL 返回使用占位符的 SQL 的指纹，用于只能拿到 SQL 字符串的场景，如 gsqltest 的期望匹配
+// 字面量替换为 ?，去掉注释，连续的占位符列表和重复的括号分组合并为一个，空白合并为一个空格
+// 无法区分值列表和其他相邻的参数，LIMIT ?,? 与 LIMIT ? 的指纹相同；构建器的指纹见 Fingerprint
+func FingerprintSQL(sql string) string {
+	return normalizeSQL(sql, true)
+}
+
+// normalizeSQL 将字面量替换为 ?，去掉注释并合并空白；collapse 时合并连续的占位符和重复的括号分组
+func normalizeSQL(sql string, collapse bool) string {
+	var sb strings.Builder
+	sb.Grow(len(sql))
+	space := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+			continue
+		case c == '`' || c == '"':
+			end := i + 1
+			for end < len(sql) && sql[end] != c {
+				end++
+			}
+			writeSpace(&sb, &space)
+			sb.WriteString(sql[i:min(end+1, len(sql))])
+			i = end
+		case c == '\'':
+			for i++; i < len(sql); i++ {
+				if sql[i] == '\\' {
+					i++
+				} else if sql[i] == '\'' {
+					if i+1 < len(sql) && sql[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			space = writePlaceholder(&sb, space, collapse)
+		case c == '/' && strings.HasPrefix(sql[i:], "/*") && !strings.HasPrefix(sql[i:], "/*+"):
+			// 注释（如 Commenter 追加的标签）不参与指纹，优化器提示保留
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+			space = true
+		case c == '?':
+			space = writePlaceholder(&sb, space, collapse)
+		case '0' <= c && c <= '9':
+			for i+1 < len(sql) && (isWordChar(sql[i+1]) || sql[i+1] == '.') {
+				i++
+			}
+			space = writePlaceholder(&sb, space, collapse)
+		case isWordChar(c):
+			end := i
+			for end < len(sql) && isWordChar(sql[end]) {
+				end++
+			}
+			writeSpace(&sb, &space)
+			sb.WriteString(sql[i:end])
+			i = end - 1
+		case c == ')':
+			space = false
+			sb.WriteByte(c)
+			if collapse {
+				collapseGroup(&sb)
+			}
+		default:
+			if c != ',' {
+				writeSpace(&sb, &space)
+			}
+			space = false
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// writeSpace 输出之前的空白，逗号和左括号之后不输出
+func writeSpace(sb *strings.Builder, space *bool) {
+	if s := sb.String(); *space && len(s) > 0 && s[len(s)-1] != ',' && s[len(s)-1] != '(' {
+		sb.WriteByte(' ')
+	}
+	*space = false
+}
+
+// writePlaceholder 写入 ?，collapse 且前面已经是 "?," 时合并为一个
+func writePlaceholder(sb *strings.Builder, space, collapse bool) bool {
+	if s := sb.String(); collapse && strings.HasSuffix(s, "?,") {
+		rest := s[:len(s)-1]
+		sb.Reset()
+		sb.WriteString(rest)
+		return false
+	}
+	writeSpace(sb, &space)
+	sb.WriteByte('?')
+	return false
+}
+
+// collapseGroup 刚写入的括号分组与前一个以逗号分隔的分组相同时去掉重复的分组
+func collapseGroup(sb *strings.Builder) {
+	s := sb.String()
+	start := groupStart(s, len(s)-1)
+	if start <= 0 || s[start-1] != ',' {
+		return
+	}
+	prevEnd := start - 2
+	if prevEnd < 0 || s[prevEnd] != ')' {
+		return
+	}
+	prevStart := groupStart(s, prevEnd)
+	if prevStart < 0 || s[prevStart:prevEnd+1] != s[start:] {
+		return
+	}
+	sb.Reset()
+	sb.WriteString(s[:start-1])
+}
+
+// groupStart 返回 s[end] 处的 ) 对应的 ( 的位置
+func groupStart(s string, end int) int {
+	depth := 0
+	for i := end; i >= 0; i-- {
+		switch s[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,163 @@
+package gsql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+)
+
+func TestFingerprintSQL(t *testing.T) {
+	tests := []struct {
+		sql    string
+		expect string
+	}{
+		{
+			"SELECT * FROM `t` WHERE `t`.`id` IN (1, 2, 3) AND `name` = 'it''s' LIMIT 10",
+			"SELECT * FROM `t` WHERE `t`.`id` IN (?) AND `name` = ? LIMIT ?",
+		},
+		{
+			"INSERT INTO `t` (`a`,`b`) VALUES (?,?),(?,?),(?,?)",
+			"INSERT INTO `t` (`a`,`b`) VALUES (?)",
+		},
+		{
+			"UPDATE  `t`\n SET `a`=?,`b`=CONCAT(`b`, ?) WHERE `t2`.`id` = ?",
+			"UPDATE `t` SET `a`=?,`b`=CONCAT(`b`,?) WHERE `t2`.`id` = ?",
+		},
+	}
+	for _, tt := range tests {
+		if got := gsql.FingerprintSQL(tt.sql); got != tt.expect {
+			t.Errorf("期望 %s，实际: %s", tt.expect, got)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	if a, b := gsql.Fingerprint(table.ID.In(1, 2, 3)), gsql.Fingerprint(table.ID.In(4)); a != b || a != "`message_consumer_progress`.`id` IN (?)" {
+		t.Errorf("IN 列表的指纹应相同: %s / %s", a, b)
+	}
+
+	q1 := gsql.SelectG[MessageConsumerProgress]().From(table).
+		Where(table.ID.In(1, 2, 3), table.ConsumerGroup.Eq("a")).Limit(10)
+	q2 := gsql.SelectG[MessageConsumerProgress]().From(table).
+		Where(table.ID.In(9), table.ConsumerGroup.Eq("b")).Limit(20)
+	q3 := gsql.SelectG[MessageConsumerProgress]().From(table).
+		Where(table.ID.In(9), table.ConsumerGroup.Not("b")).Limit(20)
+	if q1.Fingerprint() != q2.Fingerprint() {
+		t.Errorf("只有参数不同的查询指纹应相同:\n%s\n%s", q1.Fingerprint(), q2.Fingerprint())
+	}
+	if q1.Fingerprint() == q3.Fingerprint() {
+		t.Errorf("结构不同的查询指纹不应相同: %s", q3.Fingerprint())
+	}
+	if fp := q1.Fingerprint(); strings.Contains(fp, "'a'") || !strings.Contains(fp, "IN (?)") {
+		t.Errorf("指纹不应包含参数值: %s", fp)
+	}
+	if gsql.Select().From(table).Where(table.ID.In(1, 2)).Fingerprint() != gsql.Select().From(table).Where(table.ID.In(1)).Fingerprint() {
+		t.Error("QueryBuilder 的指纹应忽略 IN 列表长度")
+	}
+
+	rows := []MessageConsumerProgress{{ID: 1}, {ID: 2}, {ID: 3}}
+	many := gsql.InsertInto(table).Values(&rows)
+	one := gsql.InsertInto(table).Value(MessageConsumerProgress{ID: 4})
+	if many.Fingerprint() == "" || many.Fingerprint() != one.Fingerprint() {
+		t.Errorf("多行插入与单行插入的指纹应相同:\n%s\n%s", many.Fingerprint(), one.Fingerprint())
+	}
+	if !rows[0].CreatedAt.IsZero() {
+		t.Error("Fingerprint 不应修改要插入的值")
+	}
+	upsert := gsql.InsertInto(table).Values(&rows).DuplicateUpdate(table.GenerationID)
+	if fp := upsert.Fingerprint(); fp == one.Fingerprint() || !strings.Contains(fp, "ON DUPLICATE KEY UPDATE") {
+		t.Errorf("upsert 指纹不正确: %s", fp)
+	}
+	if fp := gsql.ReplaceInto(table).Values(&rows).Fingerprint(); !strings.HasPrefix(fp, "REPLACE INTO") {
+		t.Errorf("replace 指纹不正确: %s", fp)
+	}
+	if fp := gsql.InsertInto(table).Select(gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.In(1, 2))).Fingerprint(); !strings.Contains(fp, "SELECT") || !strings.Contains(fp, "IN (?)") {
+		t.Errorf("INSERT ... SELECT 指纹不正确: %s", fp)
+	}
+}
+
+func TestFingerprint_OnlyValueListsCollapse(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	query := func() *gsql.QueryBuilderG[MessageConsumerProgress] {
+		return gsql.SelectG[MessageConsumerProgress]().From(table)
+	}
+
+	// 相邻但不属于值列表的参数保持不变
+	if a, b := query().Limit(10).Offset(5).Fingerprint(), query().Limit(10).Fingerprint(); a == b {
+		t.Errorf("LIMIT 带 OFFSET 的指纹应不同: %s", a)
+	}
+	if a, b := gsql.Fingerprint(gsql.Expr("CONCAT(?,?,?)", "a", "b", "c")), gsql.Fingerprint(gsql.Expr("CONCAT(?)", "a")); a == b || a != "CONCAT(?,?,?)" {
+		t.Errorf("函数参数不应合并: %s / %s", a, b)
+	}
+
+	// FULL OUTER JOIN 改写后两半中的 IN 列表仍按结构合并
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+	fullJoin := func(ids ...uint64) string {
+		return gsql.Select(bb.ID).From(bb).
+			Join(gsql.FullOuterJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+			Where(bb.NFTID.In(ids...)).
+			Fingerprint()
+	}
+	if a, b := fullJoin(1, 2, 3), fullJoin(4); a != b || !strings.Contains(a, "UNION ALL") || strings.Contains(a, "?,?") {
+		t.Errorf("改写后的 IN 列表应合并:\n%s\n%s", a, b)
+	}
+}
+
+func TestExecInfoFingerprint(t *testing.T) {
+	db, _ := openCaptureDB(t, "8.0.36")
+	hook := &recordingHook{}
+	if err := db.Use(hook); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	table := NewMessageConsumerProgressTable()
+	rows := []MessageConsumerProgress{{ID: 1, ConsumerGroup: "g"}, {ID: 2, ConsumerGroup: "h"}}
+
+	query := gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.In(1, 2, 3))
+	insert := gsql.InsertInto(table).Values(&rows)
+	upsert := gsql.InsertInto(table).Values(&rows).DuplicateUpdate(table.GenerationID)
+	_, _ = query.Find(db)
+	_ = insert.Exec(db)
+	_ = upsert.Exec(db)
+
+	expect := []string{query.Fingerprint(), insert.Fingerprint(), upsert.Fingerprint()}
+	if len(hook.infos) != len(expect) {
+		t.Fatalf("期望 %d 次执行，实际: %d", len(expect), len(hook.infos))
+	}
+	for i, info := range hook.infos {
+		if info.Fingerprint != expect[i] {
+			t.Errorf("#%d ExecInfo.Fingerprint 应与构建器的指纹相同:\n%s\n%s", i, info.Fingerprint, expect[i])
+		}
+	}
+}
+
+func TestExecInfoFingerprint_BuiltStatement(t *testing.T) {
+	db, _ := openCaptureDB(t, "8.0.36")
+	hook := &recordingHook{}
+	if err := db.Use(hook); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	if err := db.Use(gsql.NewCommenter(gsql.WithStaticTags(map[string]string{"app": "svc"}))); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	table := NewMessageConsumerProgressTable()
+
+	// SQL 注释和参数个数不影响指纹，Count 使用执行时构建的 count(*) 语句
+	query := gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.In(1, 2, 3))
+	_, _ = query.Find(db)
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.In(4)).Find(db)
+	_, _ = query.Count(db)
+
+	if len(hook.infos) != 3 {
+		t.Fatalf("期望 3 次执行，实际: %d", len(hook.infos))
+	}
+	if fp := hook.infos[0].Fingerprint; fp != query.Fingerprint() || fp != hook.infos[1].Fingerprint {
+		t.Errorf("带注释的查询指纹应与构建器的指纹相同:\n%s\n%s", fp, query.Fingerprint())
+	}
+	if fp := hook.infos[2].Fingerprint; !strings.Contains(fp, "count(*)") || strings.Contains(fp, "/*") {
+		t.Errorf("Count 的指纹应来自执行的语句: %s", fp)
+	}
+}
@@ -6,9 +6,16 @@ import (
 
 	"github.com/donutnomad/gsql/clause"
 	"github.com/donutnomad/gsql/field"
+	"github.com/donutnomad/gsql/internal/clauses2"
 	"github.com/donutnomad/gsql/internal/fields"
 )
 
+// ErrRegexpMatchType RegexpLike 等方法的 matchType 不合法，执行时返回
+var ErrRegexpMatchType = clauses2.ErrRegexpMatchType
+
+// ErrInvalidEnumValue EnumField 的 Eq/In/Set 等方法收到不在 Values() 中的取值，执行时返回
+var ErrInvalidEnumValue = clauses2.ErrInvalidEnumValue
+
 // 白名单：允许的字符集
 var allowedCharsets = map[string]bool{
 	"utf8": true, "utf8mb4": true, "latin1": true,
@@ -156,6 +163,8 @@ func IFF[Result interface{ Expr() ResultExpr }, ResultExpr interface{ ExprType()
 	})
 }
 
+// COUNT_IF 统计满足条件的行数，BoolField/BoolExpr 使用 IsTrue()/IsFalse() 或 CountIf()
+// SELECT COUNT(CASE WHEN status = 'paid' THEN 1 ELSE NULL END) FROM orders;
 func COUNT_IF(condition Condition) IntExpr[int64] {
 	return COUNT(
 		IF(condition, IntVal(1), IntOf[int](nil)),
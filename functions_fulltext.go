@@ -0,0 +1,137 @@
+package gsql
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
+	"github.com/donutnomad/gsql/internal/fields"
+	"github.com/samber/lo"
+)
+
+// ==================== 全文检索 ====================
+
+// MatchMode MATCH ... AGAINST 的检索模式
+type MatchMode string
+
+const (
+	// NaturalLanguageMode 自然语言模式（默认模式）
+	NaturalLanguageMode MatchMode = "IN NATURAL LANGUAGE MODE"
+	// BooleanMode 布尔模式，支持 + - * " 等运算符，用户输入需要先经过 EscapeBooleanQuery 或 BooleanQuery 处理
+	BooleanMode MatchMode = "IN BOOLEAN MODE"
+	// QueryExpansionMode 自然语言模式加查询扩展
+	QueryExpansionMode MatchMode = "WITH QUERY EXPANSION"
+)
+
+type matcher struct {
+	columns []field.IField
+}
+
+// MATCH 全文检索，columns 需要与 FULLTEXT 索引的列完全一致
+// 数据库支持: MySQL
+// 示例:
+//
+//	score := gsql.MATCH(t.Title, t.Body).Against("database", gsql.NaturalLanguageMode)
+//	gsql.Select(t.ID, score.As("score")).From(t).
+//		Where(gsql.MATCH(t.Title, t.Body).AgainstCond("database", gsql.NaturalLanguageMode)).
+//		Order(score, false)
+//	// SELECT `id`, MATCH(`title`,`body`) AGAINST(? IN NATURAL LANGUAGE MODE) AS `score` FROM ...
+//	// WHERE MATCH(`title`,`body`) AGAINST(? IN NATURAL LANGUAGE MODE) ORDER BY MATCH(...) AGAINST(...) DESC
+func MATCH(columns ...field.IField) matcher {
+	return matcher{columns: columns}
+}
+
+// Against 返回相关度得分，可用于 SELECT 和 ORDER BY；query 作为参数绑定
+func (m matcher) Against(query string, mode MatchMode) fields.FloatExpr[float64] {
+	return fields.FloatOf[float64](m.expr(query, mode))
+}
+
+// AgainstCond 返回用于 WHERE 的条件，得分大于 0 的行满足条件
+func (m matcher) AgainstCond(query string, mode MatchMode) Condition {
+	return Condition{Expression: m.expr(query, mode)}
+}
+
+func (m matcher) expr(query string, mode MatchMode) clause.Expr {
+	if len(m.columns) == 0 {
+		panic("gsql: MATCH requires at least one column")
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(m.columns)), ",")
+	vars := lo.Map(m.columns, func(item field.IField, index int) any {
+		return item.ToExpr()
+	})
+	sql := "MATCH(" + placeholders + ") AGAINST(?"
+	if mode != "" {
+		sql += " " + string(mode)
+	}
+	return clause.Expr{SQL: sql + ")", Vars: append(vars, query)}
+}
+
+// EscapeBooleanQuery 将用户输入转换为安全的布尔模式查询串
+// 每个词用双引号包裹，词中的 + - > < ( ) ~ * @ 等运算符按普通字符处理，词之间为“或”关系
+// 示例:
+//
+//	gsql.EscapeBooleanQuery(`foo -bar "baz`) // "foo" "-bar" "baz"
+func EscapeBooleanQuery(input string) string {
+	return BooleanQuery{}.Should(input).String()
+}
+
+// BooleanQuery 布尔模式查询串构建器，传入的词都会被转义
+// 示例:
+//
+//	q := gsql.BooleanQuery{}.Must("mysql").MustNot(userInput).Prefix("data")
+//	gsql.MATCH(t.Title).AgainstCond(q.String(), gsql.BooleanMode)
+//	// +"mysql" -"..." data*
+type BooleanQuery struct {
+	terms []string
+}
+
+// Must 每个词都必须出现（+"word"）
+func (q BooleanQuery) Must(words string) BooleanQuery {
+	return q.add("+", words)
+}
+
+// MustNot 每个词都不能出现（-"word"）
+func (q BooleanQuery) MustNot(words string) BooleanQuery {
+	return q.add("-", words)
+}
+
+// Should 出现任意一个词即可，出现得越多相关度越高（"word"）
+func (q BooleanQuery) Should(words string) BooleanQuery {
+	return q.add("", words)
+}
+
+// Phrase 整个短语按顺序出现（+"word1 word2"）
+func (q BooleanQuery) Phrase(phrase string) BooleanQuery {
+	phrase = strings.Join(strings.Fields(strings.ReplaceAll(phrase, `"`, " ")), " ")
+	if phrase == "" {
+		return q
+	}
+	return BooleanQuery{terms: append(q.terms[:len(q.terms):len(q.terms)], `+"`+phrase+`"`)}
+}
+
+// Prefix 以 word 开头的词（word*），word 中只保留字母、数字和下划线
+func (q BooleanQuery) Prefix(word string) BooleanQuery {
+	word = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, word)
+	if word == "" {
+		return q
+	}
+	return BooleanQuery{terms: append(q.terms[:len(q.terms):len(q.terms)], word+"*")}
+}
+
+func (q BooleanQuery) String() string {
+	return strings.Join(q.terms, " ")
+}
+
+func (q BooleanQuery) add(operator, words string) BooleanQuery {
+	terms := q.terms[:len(q.terms):len(q.terms)]
+	for _, word := range strings.Fields(strings.ReplaceAll(words, `"`, " ")) {
+		terms = append(terms, operator+`"`+word+`"`)
+	}
+	return BooleanQuery{terms: terms}
+}
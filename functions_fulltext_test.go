@@ -0,0 +1,61 @@
+package gsql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+)
+
+func TestMatchAgainst(t *testing.T) {
+	id := gsql.IntFieldOf[int64]("posts", "id")
+	title := gsql.StringFieldOf[string]("posts", "title")
+	body := gsql.StringFieldOf[string]("posts", "body")
+
+	score := gsql.MATCH(title, body).Against("database tuning", gsql.NaturalLanguageMode)
+	sql := gsql.Select(id, score.As("score")).
+		From(gsql.TN("posts")).
+		Where(gsql.MATCH(title, body).AgainstCond("+mysql -oracle", gsql.BooleanMode)).
+		Order(score, false).
+		ToSQL()
+
+	for _, expect := range []string{
+		"MATCH(`posts`.`title`,`posts`.`body`) AGAINST('database tuning' IN NATURAL LANGUAGE MODE) AS `score`",
+		"WHERE MATCH(`posts`.`title`,`posts`.`body`) AGAINST('+mysql -oracle' IN BOOLEAN MODE)",
+		"ORDER BY MATCH(`posts`.`title`,`posts`.`body`) AGAINST('database tuning' IN NATURAL LANGUAGE MODE) DESC",
+	} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %q，实际: %s", expect, sql)
+		}
+	}
+
+	sql = gsql.Select(id).From(gsql.TN("posts")).
+		Where(gsql.MATCH(title).Against("sql", gsql.QueryExpansionMode).Gt(0.5)).
+		ToSQL()
+	if expect := "WHERE MATCH(`posts`.`title`) AGAINST('sql' WITH QUERY EXPANSION) > 0.5"; !strings.Contains(sql, expect) {
+		t.Errorf("期望包含 %q，实际: %s", expect, sql)
+	}
+}
+
+func TestBooleanQuery(t *testing.T) {
+	cases := []struct {
+		name   string
+		got    string
+		expect string
+	}{
+		{"escape", gsql.EscapeBooleanQuery(`foo -bar "baz  (qux)*`), `"foo" "-bar" "baz" "(qux)*"`},
+		{"empty", gsql.EscapeBooleanQuery(` " `), ``},
+		{
+			"builder",
+			gsql.BooleanQuery{}.Must(`mysql "`).MustNot("oracle db2").Phrase(`full "text`).Prefix("data*);").String(),
+			`+"mysql" -"oracle" -"db2" +"full text" data*`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got != tc.expect {
+				t.Errorf("期望 %s，实际: %s", tc.expect, tc.got)
+			}
+		})
+	}
+}
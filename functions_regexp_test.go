@@ -0,0 +1,141 @@
+package gsql_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/clause"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// postgresNamedDialector 只修改方言名称，用于验证按方言输出的 SQL
+type postgresNamedDialector struct {
+	*mysql.Dialector
+}
+
+func (postgresNamedDialector) Name() string { return "postgres" }
+
+func regexpSQL(t *testing.T, postgres bool, expr clause.Expression) (string, error) {
+	t.Helper()
+	var dialector gorm.Dialector = mysql.New(mysql.Config{
+		Conn:                      &capturePool{},
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	})
+	if postgres {
+		dialector = postgresNamedDialector{dialector.(*mysql.Dialector)}
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	var rows []map[string]any
+	var buildErr error
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Table("users").Where(expr).Find(&rows)
+		buildErr = tx.Error
+		return tx
+	})
+	return sql, buildErr
+}
+
+func TestStringRegexp(t *testing.T) {
+	email := gsql.StringFieldOf[string]("users", "email")
+
+	cases := []struct {
+		name   string
+		expr   clause.Expression
+		mysql  string
+		pg     string
+		pgFail bool
+	}{
+		{
+			name:  "like",
+			expr:  email.RegexpLike(`^[a-z]+@example\.com$`),
+			mysql: "REGEXP_LIKE(`users`.`email`, '^[a-z]+@example\\.com$')",
+			pg:    "`users`.`email` ~ '^[a-z]+@example\\.com$'",
+		},
+		{
+			name:  "like with match type",
+			expr:  email.RegexpLike("^abc", "i"),
+			mysql: "REGEXP_LIKE(`users`.`email`, '^abc', 'i')",
+			pg:    "`users`.`email` ~* '^abc'",
+		},
+		{
+			name:  "not like",
+			expr:  email.NotRegexpLike("^abc", "ic"),
+			mysql: "NOT REGEXP_LIKE(`users`.`email`, '^abc', 'ic')",
+			pg:    "`users`.`email` !~ '^abc'",
+		},
+		{
+			name:  "replace",
+			expr:  email.RegexpReplace("[0-9]+", "#").Eq("a#b"),
+			mysql: "REGEXP_REPLACE(`users`.`email`, '[0-9]+', '#') = 'a#b'",
+			pg:    "regexp_replace(`users`.`email`, '[0-9]+', '#', 'g') = 'a#b'",
+		},
+		{
+			name:  "replace with match type",
+			expr:  email.RegexpReplace("x", "y", "i").Eq("z"),
+			mysql: "REGEXP_REPLACE(`users`.`email`, 'x', 'y', 1, 0, 'i') = 'z'",
+			pg:    "regexp_replace(`users`.`email`, 'x', 'y', 'gi') = 'z'",
+		},
+		{
+			name:  "substr",
+			expr:  email.RegexpSubstr("@.*$", "i").Eq("@example.com"),
+			mysql: "REGEXP_SUBSTR(`users`.`email`, '@.*$', 1, 1, 'i') = '@example.com'",
+			pg:    "regexp_substr(`users`.`email`, '@.*$', 1, 1, 'i') = '@example.com'",
+		},
+		{
+			name:  "instr",
+			expr:  email.RegexpInstr("[0-9]").Gt(0),
+			mysql: "REGEXP_INSTR(`users`.`email`, '[0-9]') > 0",
+			pg:    "regexp_instr(`users`.`email`, '[0-9]') > 0",
+		},
+		{
+			name:   "unsupported match type on postgres",
+			expr:   email.RegexpLike("^a$", "m"),
+			mysql:  "REGEXP_LIKE(`users`.`email`, '^a$', 'm')",
+			pgFail: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, err := regexpSQL(t, false, tc.expr)
+			if err != nil {
+				t.Fatalf("mysql: %v", err)
+			}
+			if !strings.Contains(sql, tc.mysql) {
+				t.Errorf("mysql 期望包含 %s，实际: %s", tc.mysql, sql)
+			}
+
+			sql, err = regexpSQL(t, true, tc.expr)
+			if tc.pgFail {
+				if err == nil {
+					t.Errorf("postgres 期望返回错误，实际: %s", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("postgres: %v", err)
+			}
+			if !strings.Contains(sql, tc.pg) {
+				t.Errorf("postgres 期望包含 %s，实际: %s", tc.pg, sql)
+			}
+		})
+	}
+}
+
+func TestStringRegexpInvalidMatchType(t *testing.T) {
+	expr := gsql.StringFieldOf[string]("users", "email").RegexpLike("a", "x")
+	for _, postgres := range []bool{false, true} {
+		if _, err := regexpSQL(t, postgres, expr); !errors.Is(err, gsql.ErrRegexpMatchType) {
+			t.Errorf("postgres=%v: 期望 ErrRegexpMatchType，实际: %v", postgres, err)
+		}
+	}
+	if _, err := regexpSQL(t, true, gsql.StringFieldOf[string]("users", "email").RegexpLike("a", "m")); !errors.Is(err, gsql.ErrRegexpMatchType) {
+		t.Errorf("postgres 不支持的标志应返回 ErrRegexpMatchType，实际: %v", err)
+	}
+}
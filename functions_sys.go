@@ -152,6 +152,13 @@ func (sysFunction) UUID() fields.StringExpr[string] {
 	return fields.StringOf[string](expr("UUID()"))
 }
 
+// UUIDBin 生成一个交换时间位的 BINARY(16) UUID (UUID_TO_BIN(UUID(), 1))，用于 UUIDField/BinaryUUID 列
+// 数据库支持: MySQL 8.0+
+// 示例: gsql.Set(t.Token, gsql.Sys.UUIDBin())
+func (sysFunction) UUIDBin() fields.ScalarExpr[[]byte] {
+	return fields.UUIDToBin(expr("UUID()"), true)
+}
+
 // ==================== 已过时的方法 ====================
 
 // CurDate
@@ -0,0 +1,136 @@
+package gsql_test
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestGeometryFunctions(t *testing.T) {
+	id := gsql.IntFieldOf[int64]("stores", "id")
+	location := gsql.GeometryFieldOf[gsql.Point]("stores", "location")
+	area := gsql.GeometryFieldOf[gsql.Polygon]("regions", "area")
+
+	dist := location.DistanceSphere(gsql.LatLng(31.2304, 121.4737))
+	sql := gsql.Select(id, dist.As("dist")).
+		From(gsql.TN("stores")).
+		Where(
+			dist.Lt(5000),
+			location.Within(gsql.GeomFromText("POLYGON((0 0,10 0,10 10,0 0))", gsql.SRIDWGS84)),
+			area.MBRContains(location),
+			location.Buffer(500).Contains(gsql.NewPoint(1.5, 2.5)),
+		).
+		ToSQL()
+
+	for _, expect := range []string{
+		"ST_Distance_Sphere(`stores`.`location`, ST_SRID(POINT(121.4737, 31.2304), 4326)) AS `dist`",
+		"(ST_Distance_Sphere(`stores`.`location`, ST_SRID(POINT(121.4737, 31.2304), 4326))) < 5000",
+		"ST_Within(`stores`.`location`, (ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 0))', 4326, 'axis-order=long-lat')))",
+		"MBRContains(`regions`.`area`, `stores`.`location`)",
+		"ST_Contains(ST_Buffer(`stores`.`location`, 500), (ST_GeomFromText('POINT(2.5 1.5)', 4326, 'axis-order=long-lat')))",
+	} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %s，实际: %s", expect, sql)
+		}
+	}
+
+	sql = gsql.Select(location.AsText().As("wkt"), location.Latitude().As("lat"), gsql.GeomFromWKB([]byte{1}).SRID().As("srid")).From(gsql.TN("stores")).ToSQL()
+	for _, expect := range []string{"ST_AsText(`stores`.`location`)", "ST_Latitude(`stores`.`location`)", "ST_SRID(ST_GeomFromWKB("} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %s，实际: %s", expect, sql)
+		}
+	}
+}
+
+type geometryStore struct {
+	ID       int64
+	Location gsql.Point
+	Area     gsql.Polygon
+}
+
+func TestGeometryValue(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      &capturePool{},
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	row := geometryStore{
+		ID:       1,
+		Location: gsql.NewPoint(31.2304, 121.4737),
+		Area:     gsql.Polygon{Rings: [][]gsql.Point{{{Lng: 0, Lat: 0}, {Lng: 1, Lat: 0}, {Lng: 1, Lat: 1}, {Lng: 0, Lat: 0}}}},
+	}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("stores").Create(&row)
+	})
+	for _, expect := range []string{
+		"ST_GeomFromText('POINT(121.4737 31.2304)', 4326, 'axis-order=long-lat')",
+		"ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))')",
+	} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %s，实际: %s", expect, sql)
+		}
+	}
+}
+
+// mysqlGeometry 按 MySQL 内部格式编码：4 字节 SRID + 小端 WKB
+func mysqlGeometry(srid uint32, typ uint32, body ...any) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, srid)
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, typ)
+	for _, v := range body {
+		switch v := v.(type) {
+		case uint32:
+			b = binary.LittleEndian.AppendUint32(b, v)
+		case float64:
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+		}
+	}
+	return b
+}
+
+func TestGeometryScan(t *testing.T) {
+	var p gsql.Point
+	if err := p.Scan(mysqlGeometry(4326, 1, 121.4737, 31.2304)); err != nil {
+		t.Fatalf("scan point: %v", err)
+	}
+	if expect := gsql.NewPoint(31.2304, 121.4737); p != expect {
+		t.Errorf("期望 %+v，实际: %+v", expect, p)
+	}
+
+	var poly gsql.Polygon
+	if err := poly.Scan(mysqlGeometry(0, 3, uint32(1), uint32(4), 0.0, 0.0, 1.0, 0.0, 1.0, 1.0, 0.0, 0.0)); err != nil {
+		t.Fatalf("scan polygon: %v", err)
+	}
+	expect := gsql.Polygon{Rings: [][]gsql.Point{{{Lng: 0, Lat: 0}, {Lng: 1, Lat: 0}, {Lng: 1, Lat: 1}, {Lng: 0, Lat: 0}}}}
+	if !reflect.DeepEqual(poly, expect) {
+		t.Errorf("期望 %+v，实际: %+v", expect, poly)
+	}
+	if poly.WKT() != "POLYGON((0 0,1 0,1 1,0 0))" {
+		t.Errorf("WKT 不正确: %s", poly.WKT())
+	}
+
+	if err := p.Scan(nil); err != nil || p != (gsql.Point{}) {
+		t.Errorf("NULL 应扫描为零值，实际: %+v, %v", p, err)
+	}
+	if err := p.Scan(mysqlGeometry(4326, 3, uint32(0))); err == nil {
+		t.Error("期望类型不匹配时返回错误")
+	}
+	if err := poly.Scan(mysqlGeometry(0, 3, uint32(1), uint32(2), 0.0)); err == nil {
+		t.Error("期望数据截断时返回错误")
+	}
+	if err := poly.Scan(mysqlGeometry(0, 3, uint32(0xFFFFFFFF))); err == nil {
+		t.Error("期望环数量超过剩余数据时返回错误")
+	}
+	if err := poly.Scan(mysqlGeometry(0, 3, uint32(1), uint32(0xFFFFFFFF), 0.0, 0.0)); err == nil {
+		t.Error("期望点数量超过剩余数据时返回错误")
+	}
+}
@@ -0,0 +1,127 @@
+package gsqltest
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+var errPrepare = errors.New("gsqltest: prepared statements are not supported")
+
+// connector 将 database/sql 的连接转发到 Mock
+type connector struct {
+	m *Mock
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return conn(c), nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return c
+}
+
+func (c connector) Open(string) (driver.Conn, error) {
+	return conn(c), nil
+}
+
+type conn struct {
+	m *Mock
+}
+
+var (
+	_ driver.QueryerContext = conn{}
+	_ driver.ExecerContext  = conn{}
+	_ driver.ConnBeginTx    = conn{}
+)
+
+func (c conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errPrepare
+}
+
+func (c conn) Close() error {
+	return nil
+}
+
+func (c conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	e, err := c.m.match(ctx, true, query, values(args))
+	if err != nil {
+		return nil, err
+	}
+	if e != nil && e.err != nil {
+		return nil, e.err
+	}
+	if e == nil || e.rows == nil {
+		return &rows{}, nil
+	}
+	return &rows{columns: e.rows.columns, values: e.rows.values}, nil
+}
+
+func (c conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, err := c.m.match(ctx, false, query, values(args))
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return result{rowsAffected: 1}, nil
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return result{lastInsertID: e.lastInsertID, rowsAffected: e.rowsAffected}, nil
+}
+
+func values(args []driver.NamedValue) []any {
+	ret := make([]any, len(args))
+	for i, arg := range args {
+		ret[i] = arg.Value
+	}
+	return ret
+}
+
+// tx 事务不做任何事，语句照常记录
+type tx struct{}
+
+func (tx) Commit() error   { return nil }
+func (tx) Rollback() error { return nil }
+
+type result struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r result) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r result) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+// rows 一次查询返回的结果，每次查询独立迭代
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
@@ -0,0 +1,134 @@
+package gsqltest
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AnyArg 在 WithArgs 中匹配任意参数值
+var AnyArg any = anyArg{}
+
+type anyArg struct{}
+
+// Expectation 一条期望的语句及其返回值，通过 Mock.ExpectQuery / Mock.ExpectExec 创建
+type Expectation struct {
+	pattern     string
+	fingerprint string
+	query       bool
+	contains    bool // 语句的指纹包含 fingerprint 即匹配，见 Mock.ExpectQueryContains
+	args        []any
+	hasArgs     bool
+	times       int // 期望的执行次数，-1 表示不限次数
+	calls       int
+
+	rows         *Rows
+	lastInsertID int64
+	rowsAffected int64
+	err          error
+}
+
+// WithArgs 要求语句的参数与 args 相同，AnyArg 匹配任意值
+func (e *Expectation) WithArgs(args ...any) *Expectation {
+	e.args = make([]any, len(args))
+	for i, arg := range args {
+		if arg == AnyArg {
+			e.args[i] = arg
+			continue
+		}
+		e.args[i] = mustConvert(arg)
+	}
+	e.hasArgs = true
+	return e
+}
+
+// Times 期望被执行 n 次，默认为 1 次；0 表示不应被执行，匹配的语句按意外语句处理
+func (e *Expectation) Times(n int) *Expectation {
+	if n < 0 {
+		panic(fmt.Sprintf("gsqltest: Times(%d): n must not be negative, use AnyTimes for any number of calls", n))
+	}
+	e.times = n
+	return e
+}
+
+// AnyTimes 不限执行次数，也允许不被执行
+func (e *Expectation) AnyTimes() *Expectation {
+	e.times = -1
+	return e
+}
+
+// WillReturnRows 设置查询返回的结果行，未设置时返回空结果
+func (e *Expectation) WillReturnRows(rows *Rows) *Expectation {
+	e.rows = rows
+	return e
+}
+
+// WillReturnResult 设置执行结果，未设置时 lastInsertID 为 0，rowsAffected 为 1
+func (e *Expectation) WillReturnResult(lastInsertID, rowsAffected int64) *Expectation {
+	e.lastInsertID = lastInsertID
+	e.rowsAffected = rowsAffected
+	return e
+}
+
+// WillReturnError 执行时返回 err
+func (e *Expectation) WillReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+func (e *Expectation) String() string {
+	kind := "exec"
+	if e.query {
+		kind = "query"
+	}
+	if e.contains {
+		kind += " contains"
+	}
+	s := fmt.Sprintf("%s %q", kind, e.pattern)
+	if e.hasArgs {
+		s += fmt.Sprintf(" args %v", e.args)
+	}
+	if e.times > 1 {
+		s += fmt.Sprintf(" (%d/%d calls)", e.calls, e.times)
+	}
+	return s
+}
+
+func (e *Expectation) exhausted() bool {
+	return e.times >= 0 && e.calls >= e.times
+}
+
+func (e *Expectation) done() bool {
+	return e.times < 0 || e.calls >= e.times
+}
+
+func (e *Expectation) matches(query bool, fingerprint string, args []any) bool {
+	if e.query != query {
+		return false
+	}
+	if e.contains && !strings.Contains(fingerprint, e.fingerprint) || !e.contains && fingerprint != e.fingerprint {
+		return false
+	}
+	if !e.hasArgs {
+		return true
+	}
+	if len(e.args) != len(args) {
+		return false
+	}
+	for i, arg := range e.args {
+		if arg != AnyArg && !reflect.DeepEqual(arg, args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// mustConvert 将值转换为驱动使用的类型，与 database/sql 对参数的处理一致
+func mustConvert(v any) driver.Value {
+	ret, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		panic(fmt.Sprintf("gsqltest: %v", err))
+	}
+	return ret
+}
@@ -0,0 +1,251 @@
+// Package gsqltest 提供不连接数据库的 gsql.IDB / gsql.IGormDB 实现，用于单元测试
+//
+// Mock 记录每条执行的语句及其参数，并按预设的期望返回结果行或执行结果。
+// 期望通过指纹匹配（见 gsql.FingerprintSQL），参数值和 IN 列表长度不影响匹配；
+// ExpectQuery/ExpectExec 要求指纹完全相同，ExpectQueryContains/ExpectExecContains 只要求包含。
+// Snapshot 将构建器生成的 SQL 与 testdata 中的 golden 文件比较，使用 go test -gsqltest.update 或 GSQLTEST_UPDATE=1 更新。
+//
+// 示例:
+//
+//	m := gsqltest.New(t)
+//	q := gsql.SelectG[User]().From(u).Where(u.ID.Eq(1))
+//	m.ExpectQuery(q.Fingerprint()).WillReturnRows(gsqltest.RowsOf(User{ID: 1, Name: "a"}))
+//	m.ExpectExecContains("UPDATE `users`").WillReturnResult(0, 1)
+//	users, err := q.Find(m)
+package gsqltest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ErrUnexpectedStatement 执行的语句没有匹配的期望
+var ErrUnexpectedStatement = errors.New("gsqltest: unexpected statement")
+
+var (
+	_ gsql.IDB     = (*Mock)(nil)
+	_ gsql.IGormDB = (*Mock)(nil)
+)
+
+// Statement 一条被执行的语句
+type Statement struct {
+	SQL         string
+	Args        []any
+	Fingerprint string
+	Query       bool // 通过 QueryContext 执行，返回结果行
+}
+
+// Mock 记录语句并返回预设结果的数据库
+// 实现 gsql.IDB 与 gsql.IGormDB，可以直接传给 Find、Update、Exec 等方法
+type Mock struct {
+	db *gorm.DB
+
+	mu           sync.Mutex
+	statements   []Statement
+	expectations []*Expectation
+	unexpected   []string
+	lenient      bool
+}
+
+// Option New 的配置项
+type Option func(*options)
+
+type options struct {
+	serverVersion string
+	lenient       bool
+	config        *gorm.Config
+}
+
+// WithServerVersion 设置 MySQL 版本，影响 ROW ALIAS 等语法，默认为 8.0.36
+func WithServerVersion(version string) Option {
+	return func(o *options) {
+		o.serverVersion = version
+	}
+}
+
+// AllowUnexpected 没有匹配的期望时不返回错误：查询返回空结果，其他语句影响 1 行
+func AllowUnexpected() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// WithConfig 使用指定的 gorm 配置，默认跳过默认事务并关闭日志
+func WithConfig(config *gorm.Config) Option {
+	return func(o *options) {
+		o.config = config
+	}
+}
+
+func defaultOptions() options {
+	return options{
+		serverVersion: "8.0.36",
+		config: &gorm.Config{
+			SkipDefaultTransaction: true,
+			Logger:                 logger.Discard,
+		},
+	}
+}
+
+// New 创建 Mock，测试结束时检查所有期望都已满足且没有意外的语句
+func New(t testing.TB, opts ...Option) *Mock {
+	t.Helper()
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m, err := open(o)
+	if err != nil {
+		t.Fatalf("gsqltest: open: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := m.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	return m
+}
+
+func open(o options) (*Mock, error) {
+	m := &Mock{lenient: o.lenient}
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(connector{m: m}),
+		ServerVersion:             o.serverVersion,
+		SkipInitializeWithVersion: true,
+	}), o.config)
+	if err != nil {
+		return nil, err
+	}
+	m.db = db
+	return m, nil
+}
+
+// DB 返回底层的 gorm.DB，可用于注册插件或直接使用 gorm
+func (m *Mock) DB() *gorm.DB {
+	return m.db
+}
+
+func (m *Mock) Session(config *gorm.Session) *gorm.DB {
+	return m.db.Session(config)
+}
+
+func (m *Mock) Model(value any) *gorm.DB {
+	return m.db.Model(value)
+}
+
+// ExpectQuery 期望一条返回结果行的语句，如 SELECT 或带 RETURNING 的 INSERT
+// fingerprint 可以是构建器的 Fingerprint() 或完整 SQL，比较前都会经过 gsql.FingerprintSQL 规范化，
+// 语句的指纹与之完全相同才匹配，多出或缺少的条件都不会匹配
+func (m *Mock) ExpectQuery(fingerprint string) *Expectation {
+	return m.expect(fingerprint, true, false)
+}
+
+// ExpectExec 期望一条不返回结果行的语句，如 INSERT、UPDATE、DELETE，匹配规则见 ExpectQuery
+func (m *Mock) ExpectExec(fingerprint string) *Expectation {
+	return m.expect(fingerprint, false, false)
+}
+
+// ExpectQueryContains 与 ExpectQuery 相同，但语句的指纹包含 fragment 即匹配，为空时匹配任意查询
+// 示例: m.ExpectQueryContains("RETURNING `id`")
+func (m *Mock) ExpectQueryContains(fragment string) *Expectation {
+	return m.expect(fragment, true, true)
+}
+
+// ExpectExecContains 与 ExpectExec 相同，但语句的指纹包含 fragment 即匹配，为空时匹配任意语句
+func (m *Mock) ExpectExecContains(fragment string) *Expectation {
+	return m.expect(fragment, false, true)
+}
+
+func (m *Mock) expect(fingerprint string, query, contains bool) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &Expectation{
+		pattern:      fingerprint,
+		fingerprint:  gsql.FingerprintSQL(fingerprint),
+		query:        query,
+		contains:     contains,
+		times:        1,
+		rowsAffected: 1,
+	}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Statements 返回已执行语句的副本
+func (m *Mock) Statements() []Statement {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Statement(nil), m.statements...)
+}
+
+// SQLs 返回已执行语句的 SQL
+func (m *Mock) SQLs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make([]string, len(m.statements))
+	for i, s := range m.statements {
+		ret[i] = s.SQL
+	}
+	return ret
+}
+
+// Reset 清空已执行的语句、期望以及意外语句的记录
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statements = nil
+	m.expectations = nil
+	m.unexpected = nil
+}
+
+// ExpectationsWereMet 所有期望都已被执行且没有意外的语句时返回 nil
+func (m *Mock) ExpectationsWereMet() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var msgs []string
+	for _, e := range m.expectations {
+		if !e.done() {
+			msgs = append(msgs, fmt.Sprintf("expectation not met: %s", e))
+		}
+	}
+	for _, s := range m.unexpected {
+		msgs = append(msgs, fmt.Sprintf("unexpected statement: %s", s))
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New("gsqltest: " + strings.Join(msgs, "\n\t"))
+}
+
+// match 记录语句并返回匹配的期望，没有匹配时 lenient 模式返回 nil，否则返回错误
+func (m *Mock) match(ctx context.Context, query bool, sql string, args []any) (*Expectation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fp := gsql.FingerprintSQL(sql)
+	m.statements = append(m.statements, Statement{SQL: sql, Args: args, Fingerprint: fp, Query: query})
+	for _, e := range m.expectations {
+		if !e.exhausted() && e.matches(query, fp, args) {
+			e.calls++
+			return e, nil
+		}
+	}
+	if m.lenient {
+		return nil, nil
+	}
+	m.unexpected = append(m.unexpected, sql)
+	return nil, fmt.Errorf("%w: %s %v", ErrUnexpectedStatement, sql, args)
+}
@@ -0,0 +1,178 @@
+package gsqltest_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+)
+
+type user struct {
+	ID    int64  `gorm:"column:id;primaryKey"`
+	Email string `gorm:"column:email"`
+}
+
+func (user) TableName() string { return "users" }
+
+type userTable struct {
+	ID    gsql.IntField[int64]
+	Email gsql.StringField[string]
+}
+
+func (userTable) TableName() string { return "users" }
+func (userTable) ModelType() user   { return user{} }
+
+var users = userTable{
+	ID:    gsql.IntFieldOf[int64]("users", "id"),
+	Email: gsql.StringFieldOf[string]("users", "email"),
+}
+
+func TestMock_Find(t *testing.T) {
+	m := gsqltest.New(t)
+	q := gsql.SelectG[user]().From(users).Where(users.ID.In(1, 2))
+	// 指纹忽略参数值和 IN 列表长度
+	m.ExpectQuery(gsql.SelectG[user]().From(users).Where(users.ID.In(9)).Fingerprint()).
+		WillReturnRows(gsqltest.RowsOf(user{ID: 1, Email: "a"}, user{ID: 2, Email: "b"}))
+
+	got, err := q.Find(m)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if len(got) != 2 || got[0].Email != "a" || got[1].ID != 2 {
+		t.Errorf("unexpected rows: %+v", got)
+	}
+	stmts := m.Statements()
+	if len(stmts) != 1 || !stmts[0].Query {
+		t.Fatalf("unexpected statements: %+v", stmts)
+	}
+	if len(stmts[0].Args) != 2 || stmts[0].Args[0] != int64(1) {
+		t.Errorf("unexpected args: %v", stmts[0].Args)
+	}
+}
+
+func TestMock_FirstAndCount(t *testing.T) {
+	m := gsqltest.New(t)
+	m.ExpectQuery("SELECT count(*) FROM `users`").WillReturnRows(gsqltest.NewRows("count(*)").AddRow(3))
+	m.ExpectQueryContains("FROM `users` WHERE `users`.`email` = ?").
+		WithArgs("missing@x", gsqltest.AnyArg).
+		WillReturnRows(gsqltest.NewRows("id", "email"))
+
+	count, err := gsql.SelectG[user]().From(users).Count(m)
+	if err != nil || count != 3 {
+		t.Fatalf("count = %d, %v", count, err)
+	}
+	got, err := gsql.SelectG[user]().From(users).Where(users.Email.Eq("missing@x")).First(m)
+	if err != nil || got != nil {
+		t.Errorf("first = %+v, %v", got, err)
+	}
+}
+
+func TestMock_UpdateDeleteInsert(t *testing.T) {
+	m := gsqltest.New(t)
+	m.ExpectExec("UPDATE `users` SET `email`=? WHERE `users`.`id` = ?").WithArgs("b", int64(1)).WillReturnResult(0, 1)
+	m.ExpectExecContains("DELETE FROM `users`").WillReturnResult(0, 2)
+	m.ExpectExecContains("INSERT INTO `users`").WillReturnResult(10, 1)
+
+	ret := gsql.SelectG[user]().From(users).Where(users.ID.Eq(1)).Update(m, gsql.Set(users.Email, gsql.Lit("b")))
+	if ret.Error != nil || ret.RowsAffected != 1 {
+		t.Fatalf("update: %+v", ret)
+	}
+	ret = gsql.SelectG[user]().From(users).Where(users.ID.Gt(5)).Delete(m)
+	if ret.Error != nil || ret.RowsAffected != 2 {
+		t.Fatalf("delete: %+v", ret)
+	}
+	rows := []user{{Email: "x"}}
+	if err := gsql.InsertInto(users).Values(&rows).Exec(m); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if len(m.SQLs()) != 3 {
+		t.Errorf("expected 3 statements, got %v", m.SQLs())
+	}
+}
+
+func TestMock_Unexpected(t *testing.T) {
+	m := gsqltest.New(t)
+	_, err := gsql.SelectG[user]().From(users).Find(m)
+	if !errors.Is(err, gsqltest.ErrUnexpectedStatement) {
+		t.Fatalf("expected ErrUnexpectedStatement, got %v", err)
+	}
+	err = m.ExpectationsWereMet()
+	if err == nil || !strings.Contains(err.Error(), "unexpected statement") {
+		t.Errorf("unexpected result: %v", err)
+	}
+	m.Reset()
+}
+
+func TestMock_ExpectationNotMet(t *testing.T) {
+	m := gsqltest.New(t)
+	m.ExpectExecContains("DELETE FROM `users`").Times(2)
+	m.ExpectQueryContains("SELECT").AnyTimes()
+
+	gsql.SelectG[user]().From(users).Where(users.ID.Eq(1)).Delete(m)
+	err := m.ExpectationsWereMet()
+	if err == nil || !strings.Contains(err.Error(), "(1/2 calls)") {
+		t.Errorf("unexpected result: %v", err)
+	}
+	gsql.SelectG[user]().From(users).Where(users.ID.Eq(2)).Delete(m)
+	if err := m.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMock_ExactFingerprint(t *testing.T) {
+	m := gsqltest.New(t, gsqltest.AllowUnexpected())
+	q := gsql.SelectG[user]().From(users).Where(users.ID.Eq(1))
+	m.ExpectQuery(q.Fingerprint()).WillReturnRows(gsqltest.NewRows("id", "email").AddRow(1, "a"))
+
+	// 多出一个条件的语句不匹配精确的指纹
+	got, err := gsql.SelectG[user]().From(users).Where(users.ID.Eq(1), users.Email.Eq("a")).Find(m)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("extra predicate should not match: %+v, %v", got, err)
+	}
+	if got, err = q.Find(m); err != nil || len(got) != 1 {
+		t.Fatalf("exact fingerprint should match: %+v, %v", got, err)
+	}
+
+	// ExpectQueryContains 只要求包含
+	m.ExpectQueryContains("WHERE `users`.`id` = ?").WillReturnRows(gsqltest.NewRows("id", "email").AddRow(2, "b"))
+	got, err = gsql.SelectG[user]().From(users).Where(users.ID.Eq(2), users.Email.Eq("b")).Find(m)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("contains should match: %+v, %v", got, err)
+	}
+}
+
+func TestMock_TimesZero(t *testing.T) {
+	m := gsqltest.New(t)
+	m.ExpectExecContains("DELETE FROM `users`").Times(0)
+	if err := m.ExpectationsWereMet(); err != nil {
+		t.Fatalf("Times(0) without calls: %v", err)
+	}
+
+	ret := gsql.SelectG[user]().From(users).Where(users.ID.Eq(1)).Delete(m)
+	if !errors.Is(ret.Error, gsqltest.ErrUnexpectedStatement) {
+		t.Errorf("Times(0) should reject the statement, got %v", ret.Error)
+	}
+	m.Reset()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Times(-1) should panic")
+		}
+		m.Reset()
+	}()
+	m.ExpectExec("DELETE FROM `users`").Times(-1)
+}
+
+func TestMock_AllowUnexpected(t *testing.T) {
+	m := gsqltest.New(t, gsqltest.AllowUnexpected())
+	got, err := gsql.SelectG[user]().From(users).Find(m)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("find = %v, %v", got, err)
+	}
+	ret := gsql.SelectG[user]().From(users).Where(users.ID.Eq(1)).Delete(m)
+	if ret.Error != nil || ret.RowsAffected != 1 {
+		t.Fatalf("delete: %+v", ret)
+	}
+}
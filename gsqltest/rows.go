@@ -0,0 +1,69 @@
+package gsqltest
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+// Rows 查询返回的结果行
+type Rows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+// NewRows 创建指定列名的空结果
+// 示例:
+//
+//	gsqltest.NewRows("id", "name").AddRow(1, "a").AddRow(2, "b")
+//	gsqltest.NewRows("count(*)").AddRow(3) // Count
+func NewRows(columns ...string) *Rows {
+	return &Rows{columns: columns}
+}
+
+// AddRow 追加一行，值的数量需要与列数相同
+func (r *Rows) AddRow(values ...any) *Rows {
+	if len(values) != len(r.columns) {
+		panic(fmt.Sprintf("gsqltest: expected %d values, got %d", len(r.columns), len(values)))
+	}
+	row := make([]driver.Value, len(values))
+	for i, v := range values {
+		row[i] = mustConvert(v)
+	}
+	r.values = append(r.values, row)
+	return r
+}
+
+var schemaCache sync.Map
+
+// RowsOf 按 gorm 模型的字段生成结果行，列名使用默认的命名策略
+func RowsOf[T any](values ...T) *Rows {
+	s, err := schema.Parse(new(T), &schemaCache, schema.NamingStrategy{})
+	if err != nil {
+		panic(fmt.Sprintf("gsqltest: %v", err))
+	}
+	var fields []*schema.Field
+	for _, f := range s.Fields {
+		if f.DBName != "" && f.Readable {
+			fields = append(fields, f)
+		}
+	}
+	ret := &Rows{columns: make([]string, len(fields))}
+	for i, f := range fields {
+		ret.columns[i] = f.DBName
+	}
+	ctx := context.Background()
+	for _, v := range values {
+		rv := reflect.ValueOf(&v).Elem()
+		row := make([]any, len(fields))
+		for i, f := range fields {
+			row[i], _ = f.ValueOf(ctx, rv)
+		}
+		ret.AddRow(row...)
+	}
+	return ret
+}
@@ -0,0 +1,120 @@
+package gsqltest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
+	"gorm.io/gorm/logger"
+)
+
+// update 使用带命名空间的 -gsqltest.update，避免与测试包中已有的 -update 标志（如 goldie）冲突
+var update = flag.Bool("gsqltest.update", false, "gsqltest: update golden files in testdata")
+
+// updateSnapshots 是否更新 golden 文件：go test -gsqltest.update，或设置环境变量 GSQLTEST_UPDATE=1
+// go test ./... 中有包没有导入 gsqltest 时 -gsqltest.update 会报错，这时使用环境变量
+func updateSnapshots() bool {
+	return *update || os.Getenv("GSQLTEST_UPDATE") == "1"
+}
+
+// SnapshotDir golden 文件所在的目录，相对于测试所在包的目录
+var SnapshotDir = "testdata"
+
+// Snapshot 将 builder 生成的 SQL 与 testdata/<测试名>.sql 比较，
+// 使用 go test -gsqltest.update 或 GSQLTEST_UPDATE=1 go test 更新 golden 文件
+// builder 可以是 QueryBuilderG、QueryBuilder、Union/UnionAll 等表达式，也可以是插入构建器，
+// 插入构建器输出 Exec 执行的语句（MySQL 8.0 语法），不会连接数据库
+// SQL 使用 gsql.PrettyExpr 按子句格式化并保留占位符，参数按顺序输出在语句之后的 -- args 注释中
+// 示例:
+//
+//	func TestActiveUsers(t *testing.T) {
+//	    gsqltest.Snapshot(t, gsql.SelectG[User]().From(u).Where(u.Active.Eq(true)))
+//	}
+func Snapshot(t testing.TB, builder any) {
+	t.Helper()
+	SnapshotAs(t, t.Name(), builder)
+}
+
+// SnapshotAs 与 Snapshot 相同，使用 name 作为 golden 文件名，用于同一个测试中的多个快照
+func SnapshotAs(t testing.TB, name string, builder any) {
+	t.Helper()
+	expr, err := snapshotExpr(builder)
+	if err != nil {
+		t.Fatalf("gsqltest: snapshot %s: %v", name, err)
+	}
+	got := renderSnapshot(expr)
+	path := filepath.Join(SnapshotDir, snapshotFileName(name))
+
+	if updateSnapshots() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("gsqltest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("gsqltest: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("gsqltest: golden file %s does not exist, run go test with -gsqltest.update or GSQLTEST_UPDATE=1 to create it\n%s", path, got)
+	}
+	if err != nil {
+		t.Fatalf("gsqltest: %v", err)
+	}
+	if string(want) != got {
+		t.Errorf("gsqltest: snapshot %s does not match, run go test with -gsqltest.update or GSQLTEST_UPDATE=1 to accept\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+// snapshotExpr 按子句格式化 builder 生成的语句
+func snapshotExpr(builder any) (clause.Expr, error) {
+	var opts gsql.PrettyOptions
+	switch b := builder.(type) {
+	case interface {
+		ToPrettyExpr(gsql.PrettyOptions) (clause.Expr, error)
+	}:
+		return b.ToPrettyExpr(opts)
+	case interface{ ToExpr() clause.Expr }:
+		return gsql.PrettyExpr(b.ToExpr(), opts), nil
+	case field.IToExpr:
+		return gsql.PrettyExpr(b.ToExpr(), opts), nil
+	case clause.Expression:
+		return gsql.PrettyExpr(b, opts), nil
+	}
+	return clause.Expr{}, fmt.Errorf("unsupported builder %T", builder)
+}
+
+// renderSnapshot 输出使用占位符的 SQL，参数每行一个输出在 -- args 注释中
+func renderSnapshot(expr clause.Expr) string {
+	var sb strings.Builder
+	sb.WriteString(expr.SQL)
+	sb.WriteString(";\n")
+	if len(expr.Vars) > 0 {
+		sb.WriteString("-- args:\n")
+		for i, v := range expr.Vars {
+			fmt.Fprintf(&sb, "--   %d: %s\n", i+1, logger.ExplainSQL("?", nil, `'`, v))
+		}
+	}
+	return sb.String()
+}
+
+func snapshotFileName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !isWordByte(c) && c != '-' && c != '.' {
+			b[i] = '_'
+		}
+	}
+	return string(b) + ".sql"
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
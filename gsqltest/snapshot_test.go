@@ -0,0 +1,65 @@
+package gsqltest_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+)
+
+// 测试包自己的 -update 标志（如 goldie）不能与 gsqltest 冲突
+var _ = flag.Bool("update", false, "update golden files of another library")
+
+func TestSnapshot_UpdateEnv(t *testing.T) {
+	dir := t.TempDir()
+	old := gsqltest.SnapshotDir
+	gsqltest.SnapshotDir = dir
+	t.Cleanup(func() { gsqltest.SnapshotDir = old })
+
+	t.Setenv("GSQLTEST_UPDATE", "1")
+	gsqltest.SnapshotAs(t, "env", gsql.Select(gsql.Field("id")).From(gsql.TN("users")))
+	data, err := os.ReadFile(filepath.Join(dir, "env.sql"))
+	if err != nil {
+		t.Fatalf("GSQLTEST_UPDATE=1 应写入 golden 文件: %v", err)
+	}
+	if !strings.Contains(string(data), "FROM") {
+		t.Errorf("unexpected golden file: %s", data)
+	}
+}
+
+func TestSnapshot_Query(t *testing.T) {
+	orders := gsql.TN("orders")
+	gsqltest.Snapshot(t, gsql.SelectG[user]().From(users).
+		Where(
+			users.Email.Eq("a@x"),
+			gsql.Expr("`users`.`id` IN (?)", gsql.Select(gsql.Field("user_id")).From(orders).Where(gsql.Expr("amount > ?", 10)).ToExpr()),
+		).
+		Order(users.ID, false).
+		Limit(10))
+}
+
+func TestSnapshot_Insert(t *testing.T) {
+	rows := []user{{Email: "a"}, {Email: "b"}}
+	gsqltest.Snapshot(t, gsql.InsertInto(users).Values(&rows).DuplicateUpdate(users.Email))
+}
+
+func TestSnapshot_InsertSelect(t *testing.T) {
+	gsqltest.Snapshot(t, gsql.InsertInto(users).Select(gsql.SelectG[user]().From(users).Where(users.Email.Eq("a"))))
+}
+
+func TestSnapshot_CTEAndUnion(t *testing.T) {
+	cte := gsql.With("adults",
+		gsql.Select(gsql.Field("id")).From(gsql.TN("users")).Where(gsql.Expr("age > ?", 18)),
+	).Select(gsql.Star).From(gsql.TN("adults"))
+	gsqltest.SnapshotAs(t, "cte", cte)
+
+	union := gsql.UnionAll(
+		gsql.Select(gsql.Field("id")).From(gsql.TN("users")),
+		gsql.Select(gsql.Field("id")).From(gsql.TN("admins")).Where(gsql.Expr("level = ?", "root")),
+	)
+	gsqltest.SnapshotAs(t, "union", union)
+}
@@ -0,0 +1,6 @@
+INSERT INTO `users`
+(`email`) VALUES (?),(?)
+AS `new` ON DUPLICATE KEY UPDATE `email`=`new`.`email`;
+-- args:
+--   1: 'a'
+--   2: 'b'
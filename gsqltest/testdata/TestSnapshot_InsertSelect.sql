@@ -0,0 +1,7 @@
+INSERT INTO `users`
+(`id`,`email`)
+  SELECT *
+  FROM `users`
+  WHERE `users`.`email` = ?;
+-- args:
+--   1: 'a'
@@ -0,0 +1,13 @@
+SELECT *
+FROM `users`
+WHERE `users`.`email` = ? AND `users`.`id` IN (
+  SELECT `user_id`
+  FROM `orders`
+  WHERE amount > ?
+)
+ORDER BY `users`.`id` DESC
+LIMIT ?;
+-- args:
+--   1: 'a@x'
+--   2: 10
+--   3: 10
@@ -0,0 +1,9 @@
+WITH `adults` AS (
+  SELECT `id`
+  FROM `users`
+  WHERE age > ?
+)
+SELECT *
+FROM `adults`;
+-- args:
+--   1: 18
@@ -0,0 +1,12 @@
+(
+  SELECT `id`
+  FROM `users`
+)
+UNION ALL
+(
+  SELECT `id`
+  FROM `admins`
+  WHERE level = ?
+);
+-- args:
+--   1: 'root'
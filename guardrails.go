@@ -0,0 +1,132 @@
+package gsql
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// safetyPolicyName SafetyPolicy 注册的插件名
+const safetyPolicyName = "gsql:safety"
+
+// ErrFullTableWrite 注册了 SafetyPolicy 时 UPDATE/DELETE 的有效 WHERE 条件为空，且没有调用 AllowFullTable
+// 同时可以用 errors.Is(err, gorm.ErrMissingWhereClause) 判断
+var ErrFullTableWrite = fmt.Errorf("%w: full table update or delete", gorm.ErrMissingWhereClause)
+
+// ErrUnboundedSelect 从 SafetyPolicy 标记的大表查询时没有设置 LIMIT
+var ErrUnboundedSelect = errors.New("unbounded select on large table")
+
+// SafetyError 被安全策略拒绝的语句，Unwrap 返回 ErrFullTableWrite 或 ErrUnboundedSelect
+type SafetyError struct {
+	Operation Operation
+	Table     string
+	Err       error
+}
+
+func (e *SafetyError) Error() string {
+	return fmt.Sprintf("gsql: %s on %q refused: %v", e.Operation, e.Table, e.Err)
+}
+
+func (e *SafetyError) Unwrap() error {
+	return e.Err
+}
+
+// SafetyPolicy 执行前检查语句的安全策略，通过 db.Use 注册后只对该连接及其会话生效
+//   - UPDATE/DELETE 的有效 WHERE 条件为空时返回 ErrFullTableWrite
+//     Where 会忽略 nil 和空条件，所有过滤条件都为空时不会退化为全表操作；
+//     确需全表操作时调用 AllowFullTable，会话开启了 gorm 的 AllowGlobalUpdate 时同样放行
+//   - 从 WithLargeTables 标记的表查询时必须设置 LIMIT，否则返回 ErrUnboundedSelect
+//
+// 未注册时不做检查，没有 WHERE 的 UPDATE/DELETE 仍由 gorm 返回 gorm.ErrMissingWhereClause
+//
+// 示例:
+//
+//	db.Use(gsql.NewSafetyPolicy(gsql.WithLargeTables("events", "audit_logs")))
+//	gsql.SelectG[Event]().From(e).Where(e.Kind.Eq(kind)).Delete(db) // kind 为空时 ErrFullTableWrite
+//	gsql.SelectG[Event]().From(e).Find(db)                          // ErrUnboundedSelect
+//	gsql.SelectG[Event]().From(e).Limit(100).Find(db)               // ok
+type SafetyPolicy struct {
+	largeTables []string
+}
+
+// SafetyOption NewSafetyPolicy 的选项
+type SafetyOption func(p *SafetyPolicy)
+
+// WithLargeTables 标记大表，查询这些表时必须设置 LIMIT
+func WithLargeTables(tables ...string) SafetyOption {
+	return func(p *SafetyPolicy) {
+		p.largeTables = append(p.largeTables, tables...)
+	}
+}
+
+func NewSafetyPolicy(opts ...SafetyOption) *SafetyPolicy {
+	p := &SafetyPolicy{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *SafetyPolicy) Name() string {
+	return safetyPolicyName
+}
+
+func (p *SafetyPolicy) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// IsLargeTable 表是否被标记为大表
+func (p *SafetyPolicy) IsLargeTable(table string) bool {
+	return slices.Contains(p.largeTables, table)
+}
+
+// AllowFullTable 允许没有 WHERE 条件的 UPDATE/DELETE，同时对本次执行开启 gorm 的 AllowGlobalUpdate
+func (b *QueryBuilderG[T]) AllowFullTable() *QueryBuilderG[T] {
+	b.allowFullTable = true
+	return b
+}
+
+// checkSafety 注册了 SafetyPolicy 时按策略检查即将执行的语句，通过时返回 nil
+func (b *QueryBuilderG[T]) checkSafety(tx *GormDB, op Operation) error {
+	p, ok := tx.Config.Plugins[safetyPolicyName].(*SafetyPolicy)
+	if !ok {
+		return nil
+	}
+	table := b.safetyTable()
+	switch op {
+	case OpUpdate, OpDelete:
+		if b.allowFullTable || tx.Config.AllowGlobalUpdate || b.hasWhere() {
+			return nil
+		}
+		return &SafetyError{Operation: op, Table: table, Err: ErrFullTableWrite}
+	case OpSelect:
+		if b.limit > 0 || !p.IsLargeTable(table) {
+			return nil
+		}
+		return &SafetyError{Operation: op, Table: table, Err: ErrUnboundedSelect}
+	}
+	return nil
+}
+
+// hasWhere 是否存在构建后不为空的 WHERE 条件
+func (b *QueryBuilderG[T]) hasWhere() bool {
+	for _, expr := range b.wheres {
+		stmt := newExprDB().Statement
+		expr.Build(stmt)
+		if strings.TrimSpace(stmt.SQL.String()) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// safetyTable 策略匹配使用的表名，不包含别名
+func (b *QueryBuilderG[T]) safetyTable() string {
+	if b.from == nil {
+		return ""
+	}
+	return b.from.TableName()
+}
@@ -0,0 +1,112 @@
+package gsql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+	"gorm.io/gorm"
+)
+
+type event struct {
+	ID   int64  `gorm:"column:id;primaryKey"`
+	Kind string `gorm:"column:kind"`
+}
+
+func TestSafety_FullTableWrite(t *testing.T) {
+	m := gsqltest.New(t)
+	if err := m.DB().Use(gsql.NewSafetyPolicy()); err != nil {
+		t.Fatal(err)
+	}
+	events := gsql.TN("events")
+	kind := gsql.StringFieldOf[string]("events", "kind")
+
+	// 所有过滤条件都为空时 Where 不产生条件，不能退化为全表删除
+	ret := gsql.SelectG[event]().From(events).Where(gsql.Expr(""), nil).Delete(m)
+	var safetyErr *gsql.SafetyError
+	if !errors.As(ret.Error, &safetyErr) || safetyErr.Operation != gsql.OpDelete || safetyErr.Table != "events" {
+		t.Fatalf("expected SafetyError, got %v", ret.Error)
+	}
+	if !errors.Is(ret.Error, gsql.ErrFullTableWrite) || !errors.Is(ret.Error, gorm.ErrMissingWhereClause) {
+		t.Errorf("unexpected error chain: %v", ret.Error)
+	}
+	ret = gsql.Select().From(events).Update(m, map[string]any{"kind": "x"})
+	if !errors.Is(ret.Error, gsql.ErrFullTableWrite) {
+		t.Errorf("update: expected ErrFullTableWrite, got %v", ret.Error)
+	}
+	if n := len(m.Statements()); n != 0 {
+		t.Fatalf("refused statements must not be executed, got %d", n)
+	}
+
+	m.ExpectExec("DELETE FROM `events`").WillReturnResult(0, 7)
+	m.ExpectExec("UPDATE `events` SET `kind`=? WHERE `events`.`kind` = ?").WillReturnResult(0, 1)
+	// Scope 经过 asAny/setTo 转换后 AllowFullTable 仍然生效
+	ret = gsql.SelectG[event]().From(events).Scope(func(b *gsql.Builder) { b.AllowFullTable() }).Delete(m)
+	if ret.Error != nil || ret.RowsAffected != 7 {
+		t.Errorf("allow full table: %+v", ret)
+	}
+	ret = gsql.Select().From(events).Where(kind.Eq("a")).Update(m, map[string]any{"kind": "b"})
+	if ret.Error != nil {
+		t.Errorf("filtered update: %v", ret.Error)
+	}
+	if err := m.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSafety_UnboundedSelect(t *testing.T) {
+	m := gsqltest.New(t)
+	if err := m.DB().Use(gsql.NewSafetyPolicy(gsql.WithLargeTables("events"))); err != nil {
+		t.Fatal(err)
+	}
+	events := gsql.TN("events")
+
+	_, err := gsql.SelectG[event]().From(events).Find(m)
+	if !errors.Is(err, gsql.ErrUnboundedSelect) {
+		t.Fatalf("expected ErrUnboundedSelect, got %v", err)
+	}
+	var rows []event
+	if err := gsql.Select().From(events).Find(m, &rows); !errors.Is(err, gsql.ErrUnboundedSelect) {
+		t.Fatalf("expected ErrUnboundedSelect, got %v", err)
+	}
+
+	m.ExpectQuery("SELECT * FROM `events` LIMIT ?").WillReturnRows(gsqltest.RowsOf(event{ID: 1, Kind: "a"}))
+	m.ExpectQuery("SELECT * FROM `users`").WillReturnRows(gsqltest.NewRows("id", "kind"))
+	m.ExpectQuery("SELECT count(*) FROM `events`").WillReturnRows(gsqltest.NewRows("count(*)").AddRow(3))
+	if got, err := gsql.SelectG[event]().From(events).Limit(10).Find(m); err != nil || len(got) != 1 {
+		t.Errorf("limited select: %v %v", got, err)
+	}
+	if _, err := gsql.SelectG[event]().From(gsql.TN("users")).Find(m); err != nil {
+		t.Errorf("small table: %v", err)
+	}
+	// COUNT 只返回一行，不受限制
+	if n, err := gsql.SelectG[event]().From(events).Count(m); err != nil || n != 3 {
+		t.Errorf("count: %d %v", n, err)
+	}
+	if err := m.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSafety_WithoutPolicy(t *testing.T) {
+	m := gsqltest.New(t)
+	events := gsql.TN("events")
+
+	// 未注册策略时不做检查，由 gorm 自身拒绝没有 WHERE 的删除
+	ret := gsql.SelectG[event]().From(events).Delete(m)
+	var safetyErr *gsql.SafetyError
+	if errors.As(ret.Error, &safetyErr) || !errors.Is(ret.Error, gorm.ErrMissingWhereClause) {
+		t.Fatalf("expected gorm.ErrMissingWhereClause, got %v", ret.Error)
+	}
+
+	// 注册策略后，会话开启 AllowGlobalUpdate 时同样放行
+	if err := m.DB().Use(gsql.NewSafetyPolicy()); err != nil {
+		t.Fatal(err)
+	}
+	m.ExpectExec("DELETE FROM `events`").WillReturnResult(0, 3)
+	ret = gsql.SelectG[event]().From(events).Delete(m.DB().Session(&gorm.Session{AllowGlobalUpdate: true}))
+	if ret.Error != nil || ret.RowsAffected != 3 {
+		t.Errorf("allow global update: %+v", ret)
+	}
+}
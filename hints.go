@@ -0,0 +1,413 @@
+package gsql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/donutnomad/gsql/clause"
+)
+
+// ErrInvalidHint 优化器提示的名字或 SET_VAR 的值不合法，执行时返回
+var ErrInvalidHint = errors.New("invalid optimizer hint")
+
+// OptimizerHint MySQL 8 优化器提示，通过 Hint 方法输出到 SELECT/UPDATE/DELETE 之后的 /*+ ... */ 中
+// 引用表的提示在构建 SQL 时才解析表名，表设置了别名时使用别名
+// 参数不合法的提示不会输出，执行时返回 ErrInvalidHint
+type OptimizerHint struct {
+	name    string
+	tables  []ITableName
+	indexes []string
+	args    []string
+	err     error
+}
+
+// Err 提示参数不合法时返回 ErrInvalidHint
+func (h OptimizerHint) Err() error {
+	return h.err
+}
+
+// SemijoinStrategy SEMIJOIN/NO_SEMIJOIN 提示的策略
+type SemijoinStrategy string
+
+const (
+	SemijoinDupsWeedout     SemijoinStrategy = "DUPSWEEDOUT"
+	SemijoinFirstMatch      SemijoinStrategy = "FIRSTMATCH"
+	SemijoinLooseScan       SemijoinStrategy = "LOOSESCAN"
+	SemijoinMaterialization SemijoinStrategy = "MATERIALIZATION"
+)
+
+// SubqueryStrategy SUBQUERY 提示的策略
+type SubqueryStrategy string
+
+const (
+	SubqueryIntoExists      SubqueryStrategy = "INTOEXISTS"
+	SubqueryMaterialization SubqueryStrategy = "MATERIALIZATION"
+)
+
+// ---------- 连接顺序 ----------
+
+// HintJoinFixedOrder JOIN_FIXED_ORDER()，按 FROM 子句中的顺序连接，等同于 STRAIGHT_JOIN
+func HintJoinFixedOrder() OptimizerHint {
+	return OptimizerHint{name: "JOIN_FIXED_ORDER"}
+}
+
+// HintJoinOrder JOIN_ORDER(t1, t2, ...)，按给定顺序连接表
+func HintJoinOrder(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "JOIN_ORDER", tables: tables}
+}
+
+// HintJoinPrefix JOIN_PREFIX(t1, ...)，指定的表排在连接顺序的最前面
+func HintJoinPrefix(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "JOIN_PREFIX", tables: tables}
+}
+
+// HintJoinSuffix JOIN_SUFFIX(t1, ...)，指定的表排在连接顺序的最后面
+func HintJoinSuffix(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "JOIN_SUFFIX", tables: tables}
+}
+
+// ---------- 表级提示，不传表时作用于整个查询块 ----------
+
+// HintBKA BKA(t1, ...)，使用 Batched Key Access 连接
+func HintBKA(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "BKA", tables: tables}
+}
+
+// HintNoBKA NO_BKA(t1, ...)
+func HintNoBKA(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "NO_BKA", tables: tables}
+}
+
+// HintBNL BNL(t1, ...)，MySQL 8.0.20+ 中等同于 HASH_JOIN
+func HintBNL(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "BNL", tables: tables}
+}
+
+// HintNoBNL NO_BNL(t1, ...)
+func HintNoBNL(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "NO_BNL", tables: tables}
+}
+
+// HintHashJoin HASH_JOIN(t1, ...)
+func HintHashJoin(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "HASH_JOIN", tables: tables}
+}
+
+// HintNoHashJoin NO_HASH_JOIN(t1, ...)
+func HintNoHashJoin(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "NO_HASH_JOIN", tables: tables}
+}
+
+// HintMerge MERGE(dt, ...)，将派生表/视图/CTE 合并到外层查询
+func HintMerge(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "MERGE", tables: tables}
+}
+
+// HintNoMerge NO_MERGE(dt, ...)，将派生表/视图/CTE 物化
+func HintNoMerge(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "NO_MERGE", tables: tables}
+}
+
+// HintDerivedConditionPushdown DERIVED_CONDITION_PUSHDOWN(dt, ...)
+func HintDerivedConditionPushdown(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "DERIVED_CONDITION_PUSHDOWN", tables: tables}
+}
+
+// HintNoDerivedConditionPushdown NO_DERIVED_CONDITION_PUSHDOWN(dt, ...)
+func HintNoDerivedConditionPushdown(tables ...ITableName) OptimizerHint {
+	return OptimizerHint{name: "NO_DERIVED_CONDITION_PUSHDOWN", tables: tables}
+}
+
+// ---------- 索引级提示，不传索引时作用于表的所有索引 ----------
+
+// HintIndex INDEX(t idx1, idx2, ...)，只使用指定的索引
+func HintIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("INDEX", table, indexes)
+}
+
+// HintNoIndex NO_INDEX(t idx1, ...)
+func HintNoIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_INDEX", table, indexes)
+}
+
+// HintIndexMerge INDEX_MERGE(t idx1, idx2, ...)，使用指定索引的 Index Merge
+func HintIndexMerge(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("INDEX_MERGE", table, indexes)
+}
+
+// HintNoIndexMerge NO_INDEX_MERGE(t idx1, ...)
+func HintNoIndexMerge(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_INDEX_MERGE", table, indexes)
+}
+
+// HintJoinIndex JOIN_INDEX(t idx1, ...)，连接时使用指定的索引
+func HintJoinIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("JOIN_INDEX", table, indexes)
+}
+
+// HintNoJoinIndex NO_JOIN_INDEX(t idx1, ...)
+func HintNoJoinIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_JOIN_INDEX", table, indexes)
+}
+
+// HintGroupIndex GROUP_INDEX(t idx1, ...)，GROUP BY 使用指定的索引
+func HintGroupIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("GROUP_INDEX", table, indexes)
+}
+
+// HintNoGroupIndex NO_GROUP_INDEX(t idx1, ...)
+func HintNoGroupIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_GROUP_INDEX", table, indexes)
+}
+
+// HintOrderIndex ORDER_INDEX(t idx1, ...)，ORDER BY 使用指定的索引
+func HintOrderIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("ORDER_INDEX", table, indexes)
+}
+
+// HintNoOrderIndex NO_ORDER_INDEX(t idx1, ...)
+func HintNoOrderIndex(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_ORDER_INDEX", table, indexes)
+}
+
+// HintMRR MRR(t idx1, ...)，使用 Multi-Range Read
+func HintMRR(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("MRR", table, indexes)
+}
+
+// HintNoMRR NO_MRR(t idx1, ...)
+func HintNoMRR(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_MRR", table, indexes)
+}
+
+// HintNoICP NO_ICP(t idx1, ...)，禁用索引条件下推
+func HintNoICP(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_ICP", table, indexes)
+}
+
+// HintNoRangeOptimization NO_RANGE_OPTIMIZATION(t idx1, ...)
+func HintNoRangeOptimization(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_RANGE_OPTIMIZATION", table, indexes)
+}
+
+// HintSkipScan SKIP_SCAN(t idx1, ...)
+func HintSkipScan(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("SKIP_SCAN", table, indexes)
+}
+
+// HintNoSkipScan NO_SKIP_SCAN(t idx1, ...)
+func HintNoSkipScan(table ITableName, indexes ...string) OptimizerHint {
+	return indexHintOf("NO_SKIP_SCAN", table, indexes)
+}
+
+// ---------- 子查询 ----------
+
+// HintSemijoin SEMIJOIN(strategy, ...)，只允许使用指定的半连接策略，不传时由优化器选择
+func HintSemijoin(strategies ...SemijoinStrategy) OptimizerHint {
+	return OptimizerHint{name: "SEMIJOIN", args: strategyArgs(strategies)}
+}
+
+// HintNoSemijoin NO_SEMIJOIN(strategy, ...)，禁用指定的半连接策略，不传时禁用半连接
+func HintNoSemijoin(strategies ...SemijoinStrategy) OptimizerHint {
+	return OptimizerHint{name: "NO_SEMIJOIN", args: strategyArgs(strategies)}
+}
+
+// HintSubquery SUBQUERY(strategy)
+func HintSubquery(strategy SubqueryStrategy) OptimizerHint {
+	return OptimizerHint{name: "SUBQUERY", args: []string{string(strategy)}}
+}
+
+// ---------- 其他 ----------
+
+// HintMaxExecutionTime MAX_EXECUTION_TIME(ms)，只对 SELECT 生效，通常使用 Timeout 代替
+func HintMaxExecutionTime(d time.Duration) OptimizerHint {
+	return OptimizerHint{name: "MAX_EXECUTION_TIME", args: []string{strconv.FormatInt(max(d.Milliseconds(), 1), 10)}}
+}
+
+// HintSetVar SET_VAR(name = value)，仅在本条语句执行期间修改系统变量
+// value 支持整数、浮点数、bool（输出 ON/OFF）和字符串（输出为带引号的字符串），包括以它们为底层类型的命名类型
+//
+// 示例:
+//
+//	gsql.HintSetVar("sort_buffer_size", 16<<20)
+//	gsql.HintSetVar("optimizer_switch", "mrr_cost_based=off")
+func HintSetVar(name string, value any) OptimizerHint {
+	if err := checkHintIdentifier(name); err != nil {
+		return OptimizerHint{name: "SET_VAR", err: err}
+	}
+	v, err := hintValue(value)
+	if err != nil {
+		return OptimizerHint{name: "SET_VAR", err: err}
+	}
+	return OptimizerHint{name: "SET_VAR", args: []string{name + " = " + v}}
+}
+
+// HintResourceGroup RESOURCE_GROUP(name)，在指定的资源组中执行
+func HintResourceGroup(name string) OptimizerHint {
+	return OptimizerHint{name: "RESOURCE_GROUP", args: []string{name}, err: checkHintIdentifier(name)}
+}
+
+// HintQBName QB_NAME(name)，为当前查询块命名
+func HintQBName(name string) OptimizerHint {
+	return OptimizerHint{name: "QB_NAME", args: []string{name}, err: checkHintIdentifier(name)}
+}
+
+func indexHintOf(name string, table ITableName, indexes []string) OptimizerHint {
+	return OptimizerHint{name: name, tables: []ITableName{table}, indexes: indexes}
+}
+
+func strategyArgs[S ~string](strategies []S) []string {
+	args := make([]string, 0, len(strategies))
+	for _, s := range strategies {
+		args = append(args, string(s))
+	}
+	return args
+}
+
+var hintIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func checkHintIdentifier(name string) error {
+	if !hintIdentifier.MatchString(name) {
+		return fmt.Errorf("%w: identifier %q", ErrInvalidHint, name)
+	}
+	return nil
+}
+
+// hintValue 格式化 SET_VAR 的值，字符串中的引号和注释结束符会被转义
+// 按 reflect.Kind 判断，time.Duration 等命名类型按底层类型输出
+func hintValue(value any) (string, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return "ON", nil
+		}
+		return "OFF", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.String:
+		s := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "*/", `*\/`).Replace(rv.String())
+		return "'" + s + "'", nil
+	default:
+		return "", fmt.Errorf("%w: unsupported SET_VAR value type %T", ErrInvalidHint, value)
+	}
+}
+
+// hintsError 返回第一个不合法的提示的错误
+func hintsError(hints []OptimizerHint) error {
+	for _, h := range hints {
+		if h.err != nil {
+			return h.err
+		}
+	}
+	return nil
+}
+
+// hintTableName 返回提示中引用表的名字，有别名时使用别名
+func hintTableName(table ITableName) string {
+	if v, ok := table.(interface{ Alias() string }); ok && v.Alias() != "" {
+		return v.Alias()
+	}
+	return table.TableName()
+}
+
+func (h OptimizerHint) build(quote func(string) string) string {
+	var sb strings.Builder
+	sb.WriteString(h.name)
+	sb.WriteByte('(')
+	for i, t := range h.tables {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(quote(hintTableName(t)))
+	}
+	for i, idx := range h.indexes {
+		if i == 0 {
+			sb.WriteByte(' ')
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(quote(idx))
+	}
+	sb.WriteString(strings.Join(h.args, ", "))
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// hintComment 输出 /*+ hint1 hint2 */
+type hintComment struct {
+	hints []OptimizerHint
+	quote func(string) string
+}
+
+func (c hintComment) Build(builder clause.Builder) {
+	_, _ = builder.WriteString(c.String())
+}
+
+func (c hintComment) String() string {
+	var sb strings.Builder
+	sb.WriteString("/*+ ")
+	for i, h := range c.hints {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(h.build(c.quote))
+	}
+	sb.WriteString(" */")
+	return sb.String()
+}
+
+// Hint 添加优化器提示，输出在 SELECT 之后；执行 Update/Delete 时输出在 UPDATE/DELETE 之后
+//
+// 示例:
+//
+//	gsql.Select(u.ID).From(u).Join(gsql.InnerJoin(o).On(...)).
+//		Hint(gsql.HintJoinOrder(o, u), gsql.HintIndex(u, "idx_status"), gsql.HintSetVar("sort_buffer_size", 16<<20))
+//	// SELECT /*+ JOIN_ORDER(`o`, `u`) INDEX(`u` `idx_status`) SET_VAR(sort_buffer_size = 16777216) */ ...
+func (b *QueryBuilderG[T]) Hint(hints ...OptimizerHint) *QueryBuilderG[T] {
+	b.hints = append(b.hints, hints...)
+	return b
+}
+
+// Hint 添加优化器提示，见 QueryBuilderG.Hint
+func (b *QueryBuilder) Hint(hints ...OptimizerHint) *QueryBuilder {
+	b.as().Hint(hints...)
+	return b
+}
+
+// addOptimizerHints 将优化器提示写入 SELECT/UPDATE/DELETE 子句名之后，Timeout 只作用于 SELECT
+// 不合法的提示不会输出，由 build 返回 ErrInvalidHint
+func (b *QueryBuilderG[T]) addOptimizerHints(stmt *Statement, quote func(string) string) {
+	valid := slices.DeleteFunc(slices.Clone(b.hints), func(h OptimizerHint) bool { return h.err != nil })
+	for _, name := range []string{"SELECT", "UPDATE", "DELETE"} {
+		hints := valid
+		if name == "SELECT" {
+			if h, ok := b.maxExecutionTimeHint(stmt); ok {
+				hints = append(hints[:len(hints):len(hints)], h)
+			}
+		}
+		if len(hints) == 0 {
+			continue
+		}
+		comment := hintComment{hints: hints, quote: quote}
+		c := stmt.Clauses[name]
+		if name == "DELETE" {
+			// clause.Delete 自行输出 DELETE 关键字，提示作为修饰符写在其后
+			c.Expression = clause.Delete{Modifier: comment.String()}
+		} else {
+			c.Name = name
+			c.AfterNameExpression = comment
+		}
+		stmt.Clauses[name] = c
+	}
+}
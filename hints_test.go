@@ -0,0 +1,122 @@
+package gsql_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donutnomad/gsql"
+)
+
+func TestHint_Select(t *testing.T) {
+	bb := balanceRecordSchemaBase.As("bb")
+	ror := rorRecordSchemaBase.As("ror")
+
+	sql := gsql.Select(bb.ID).
+		From(bb).
+		Join(gsql.LeftJoin(ror).On(ror.NFTID.EqF(bb.NFTID))).
+		Hint(
+			gsql.HintJoinOrder(ror, bb),
+			gsql.HintBKA(ror),
+			gsql.HintIndexMerge(bb, "idx_account", "idx_contract"),
+			gsql.HintNoMerge(),
+			gsql.HintSemijoin(gsql.SemijoinFirstMatch, gsql.SemijoinLooseScan),
+			gsql.HintSetVar("sort_buffer_size", 16<<20),
+			gsql.HintResourceGroup("batch"),
+		).
+		ToSQL()
+	expect := "SELECT /*+ JOIN_ORDER(`ror`, `bb`) BKA(`ror`) INDEX_MERGE(`bb` `idx_account`, `idx_contract`) NO_MERGE() " +
+		"SEMIJOIN(FIRSTMATCH, LOOSESCAN) SET_VAR(sort_buffer_size = 16777216) RESOURCE_GROUP(batch) */ `bb`.`id` FROM"
+	if !strings.HasPrefix(sql, expect) {
+		t.Errorf("期望以 %q 开头，实际: %s", expect, sql)
+	}
+
+	// 表的别名变化时提示中的表名随之变化
+	sql = gsql.Select(bb.ID).From(balanceRecordSchemaBase).Hint(gsql.HintIndex(balanceRecordSchemaBase, "idx_account")).ToSQL()
+	if !strings.HasPrefix(sql, "SELECT /*+ INDEX(`balance_records` `idx_account`) */ ") {
+		t.Errorf("未设置别名时应使用表名，实际: %s", sql)
+	}
+}
+
+func TestHint_WithTimeout(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	sql := gsql.Select(table.ID).From(table).Hint(gsql.HintNoICP(table)).Timeout(time.Second).ToSQL()
+	expect := "SELECT /*+ NO_ICP(`message_consumer_progress`) MAX_EXECUTION_TIME(1000) */ "
+	if !strings.HasPrefix(sql, expect) {
+		t.Errorf("期望以 %q 开头，实际: %s", expect, sql)
+	}
+}
+
+func TestHint_UpdateDelete(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, pool := openCaptureDB(t, "8.0.36")
+
+	gsql.SelectG[MessageConsumerProgress]().From(table).
+		Where(table.ID.Eq(1)).
+		Hint(gsql.HintIndex(table, "PRIMARY")).
+		Timeout(time.Second).
+		Update(db, map[string]any{"generation_id": 2})
+	gsql.SelectG[MessageConsumerProgress]().From(table).
+		Where(table.ID.Eq(1)).
+		Hint(gsql.HintResourceGroup("batch")).
+		Delete(db)
+	if len(pool.sqls) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(pool.sqls))
+	}
+	if expect := "UPDATE /*+ INDEX(`message_consumer_progress` `PRIMARY`) */ `message_consumer_progress` SET "; !strings.HasPrefix(pool.sqls[0], expect) {
+		t.Errorf("期望以 %q 开头，实际: %s", expect, pool.sqls[0])
+	}
+	if expect := "DELETE /*+ RESOURCE_GROUP(batch) */ FROM `message_consumer_progress`"; !strings.HasPrefix(pool.sqls[1], expect) {
+		t.Errorf("期望以 %q 开头，实际: %s", expect, pool.sqls[1])
+	}
+}
+
+func TestHint_SetVarEscape(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	sql := gsql.Select(table.ID).From(table).Hint(gsql.HintSetVar("optimizer_switch", "a='b' */ DROP")).ToSQL()
+	if strings.Count(sql, "*/") != 1 {
+		t.Errorf("字符串值不应结束注释，实际: %s", sql)
+	}
+
+}
+
+func TestHint_Invalid(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	// 命名类型按底层类型输出
+	type mode string
+	sql := gsql.Select(table.ID).From(table).Hint(
+		gsql.HintSetVar("max_execution_time", time.Duration(5)),
+		gsql.HintSetVar("sql_mode", mode("ANSI")),
+		gsql.HintSetVar("optimizer_prune_level", uint8(1)),
+	).ToSQL()
+	expect := "SELECT /*+ SET_VAR(max_execution_time = 5) SET_VAR(sql_mode = 'ANSI') SET_VAR(optimizer_prune_level = 1) */ "
+	if !strings.HasPrefix(sql, expect) {
+		t.Errorf("期望以 %q 开头，实际: %s", expect, sql)
+	}
+
+	// 不合法的提示不输出，执行时返回 ErrInvalidHint
+	for _, h := range []gsql.OptimizerHint{
+		gsql.HintSetVar("x = 1) */ DROP TABLE t; --", 1),
+		gsql.HintSetVar("sort_buffer_size", []int{1}),
+		gsql.HintQBName("qb) */ DROP"),
+	} {
+		if !errors.Is(h.Err(), gsql.ErrInvalidHint) {
+			t.Errorf("期望 ErrInvalidHint，实际: %v", h.Err())
+		}
+		db, pool := openCaptureDB(t, "8.0.36")
+		q := gsql.SelectG[MessageConsumerProgress]().From(table).Hint(h)
+		if sql := q.ToSQL(); strings.Contains(sql, "/*+") {
+			t.Errorf("不合法的提示不应输出: %s", sql)
+		}
+		if _, err := q.Find(db); !errors.Is(err, gsql.ErrInvalidHint) {
+			t.Errorf("期望执行时返回 ErrInvalidHint，实际: %v", err)
+		}
+		if len(pool.sqls) != 0 {
+			t.Errorf("不应执行语句: %v", pool.sqls)
+		}
+	}
+}
@@ -1,13 +1,17 @@
 package gsql
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/donutnomad/gsql/clause"
 	"github.com/donutnomad/gsql/field"
+	"github.com/donutnomad/gsql/internal/clauses2"
 	"github.com/donutnomad/gsql/internal/fieldi"
 	"github.com/donutnomad/gsql/internal/types"
 	"github.com/donutnomad/gsql/internal/utils"
@@ -26,20 +30,18 @@ import (
 
 // INSERT INTO ... SELECT
 
+// REPLACE INTO ... VALUES
 // REPLACE INTO ... SELECT
 // * DELETE + INSERT, 先删除后插入，id会自增
 // * 如果新数据某些列没有提供值，它们将使用默认值，旧数据中未提供的列的值会丢失
 // * 会触发 DELETE 和 INSERT 相关的触发器
 // * 通常性能较低，特别是索引多或有复杂触发器时
 
-// Assignment 表示 ON DUPLICATE KEY UPDATE 中的赋值表达式
-// 用于支持自定义更新逻辑，如 column = IF(condition, newValue, oldValue)
-type Assignment struct {
-	Column fieldi.IField
-	Value  Expression
-}
+// ErrEmptyDuplicateUpdate DuplicateUpdateNonKeys/DuplicateUpdateAllExcept 没有得到任何要更新的列，
+// 或 OnlyIfNewer 没有可以保护的赋值，执行时返回
+var ErrEmptyDuplicateUpdate = errors.New("empty ON DUPLICATE KEY UPDATE assignment list")
 
-// Set 创建一个赋值表达式，用于 ON DUPLICATE KEY UPDATE
+// Set 创建一个赋值表达式，用于 ON DUPLICATE KEY UPDATE，也可以直接传给 Update
 // 示例:
 //
 //	// 简单更新：使用插入行的值更新
@@ -61,63 +63,194 @@ func Set(column fieldi.IField, value Expression) Assignment {
 
 type InsertBuilder[T any] struct {
 	ignore        bool
+	table         any
 	selectColumns []field.IField
 }
 
 type insertBuilderWithValues[T any] struct {
+	table             any
 	selectColumns     []field.IField
 	duplicateUpdates  []Assignment
 	values            *[]T
 	onDuplicateUpdate bool
 	ignore            bool
+	replace           bool
+	rowAlias          string
+	newerField        field.IField
+	emptyUpdate       string // 计算出的赋值列表为空的方法名，执行时返回 ErrEmptyDuplicateUpdate
+	fillIDs           bool
+	ctx               context.Context
+	tags              map[string]string
 }
 
 func InsertInto[T any, TableTypes interface {
 	ModelType() T
-}](_ TableTypes, columns ...field.IField) *InsertBuilder[T] {
+}](table TableTypes, columns ...field.IField) *InsertBuilder[T] {
 	return &InsertBuilder[T]{
+		table:         table,
 		selectColumns: columns,
 	}
 }
 
 func InsertIgnore[T any, TableTypes interface {
 	ModelType() T
-}](_ TableTypes, columns ...field.IField) *InsertBuilder[T] {
+}](table TableTypes, columns ...field.IField) *InsertBuilder[T] {
 	return &InsertBuilder[T]{
 		ignore:        true,
+		table:         table,
 		selectColumns: columns,
 	}
 }
 
 func (b *InsertBuilder[T]) Value(value T) *insertBuilderWithValues[T] {
-	return &insertBuilderWithValues[T]{
-		selectColumns: b.selectColumns,
-		values:        &[]T{value},
-		ignore:        b.ignore,
-	}
+	return b.Values(&[]T{value})
 }
 
 func (b *InsertBuilder[T]) Values(values *[]T) *insertBuilderWithValues[T] {
 	return &insertBuilderWithValues[T]{
+		table:         b.table,
 		selectColumns: b.selectColumns,
 		values:        values,
 		ignore:        b.ignore,
 	}
 }
 
+// As 设置插入行的别名，默认为 DefaultRowAlias
+// 需要与 RowAlias(t, alias) 使用相同的名字
+func (b *insertBuilderWithValues[T]) As(alias string) *insertBuilderWithValues[T] {
+	b.rowAlias = alias
+	return b
+}
+
+// WithContext 设置执行时使用的 context
+func (b *insertBuilderWithValues[T]) WithContext(ctx context.Context) *insertBuilderWithValues[T] {
+	b.ctx = ctx
+	return b
+}
+
+// Tag 设置 SQL 注释标签，见 Commenter
+func (b *insertBuilderWithValues[T]) Tag(key, value string) *insertBuilderWithValues[T] {
+	b.tags = addTag(b.tags, key, value)
+	return b
+}
+
+// DuplicateUpdate 使用插入行的值更新指定列
+// MySQL 8.0.19+ 输出 `col`=`new`.`col`，其他版本输出 `col`=VALUES(`col`)
+// 设置了 ON DUPLICATE KEY UPDATE 的语句不经过 gorm 的 Create 回调执行，模型的 BeforeCreate/AfterCreate 不会被调用
 func (b *insertBuilderWithValues[T]) DuplicateUpdate(columns ...field.IField) *insertBuilderWithValues[T] {
 	b.onDuplicateUpdate = true
-	// 将列转换为 Assignment，使用 Values 引用插入行的值
+	// 将列转换为 Assignment，使用行别名引用插入行的值
 	for _, col := range columns {
 		b.duplicateUpdates = append(b.duplicateUpdates, Assignment{
 			Column: col,
-			Value:  clause.Expr{SQL: "VALUES(?)", Vars: []any{col}},
+			Value:  rowValue(col),
 		})
 	}
 	return b
 }
 
+// DuplicateUpdateNonKeys 使用插入行的值更新所有非键列
+// 跳过带有 FlagPrimaryKey、FlagUniqueIndex、FlagAutoIncrement 标志的字段
+// 字段列表来自表结构的 AllFields()，未实现时使用结构体中的所有字段
+// 所有列都是键时执行返回 ErrEmptyDuplicateUpdate
+func (b *insertBuilderWithValues[T]) DuplicateUpdateNonKeys() *insertBuilderWithValues[T] {
+	columns := lo.Filter(tableFields(b.table), func(item field.IField, index int) bool {
+		return !isKeyField(item)
+	})
+	if len(columns) == 0 {
+		b.emptyUpdate = "DuplicateUpdateNonKeys"
+	}
+	return b.DuplicateUpdate(columns...)
+}
+
+// DuplicateUpdateAllExcept 使用插入行的值更新除 excludes 以外的所有列
+// 主键和自增列始终不会被更新，排除了所有列时执行返回 ErrEmptyDuplicateUpdate
+//
+//	gsql.InsertInto(t).Values(&rows).DuplicateUpdateAllExcept(t.CreatedAt)
+func (b *insertBuilderWithValues[T]) DuplicateUpdateAllExcept(excludes ...field.IField) *insertBuilderWithValues[T] {
+	names := lo.Map(excludes, func(item field.IField, index int) string {
+		return item.Name()
+	})
+	columns := lo.Filter(tableFields(b.table), func(item field.IField, index int) bool {
+		return !lo.Contains(names, item.Name()) &&
+			!hasFieldFlag(item, field.FlagPrimaryKey) && !hasFieldFlag(item, field.FlagAutoIncrement)
+	})
+	if len(columns) == 0 {
+		b.emptyUpdate = "DuplicateUpdateAllExcept"
+	}
+	return b.DuplicateUpdate(columns...)
+}
+
+// OnlyIfNewer 只有当插入行的 newer 字段（版本号或时间戳）大于现有行时才更新
+// 作用于所有赋值，包括 DuplicateUpdateExpr 中的自定义表达式；newer 列的赋值会被移到最后，保证其他列比较的是旧值
+//
+//	// ON DUPLICATE KEY UPDATE `value`=IF(`new`.`version` > `t`.`version`, `new`.`value`, `t`.`value`),
+//	//                         `version`=IF(`new`.`version` > `t`.`version`, `new`.`version`, `t`.`version`)
+//	gsql.InsertInto(t).Values(&rows).DuplicateUpdateNonKeys().OnlyIfNewer(t.Version)
+//
+// 没有任何赋值时执行返回 ErrEmptyDuplicateUpdate
+func (b *insertBuilderWithValues[T]) OnlyIfNewer(newer field.IField) *insertBuilderWithValues[T] {
+	b.newerField = newer
+	return b
+}
+
+// checkDuplicateUpdate 计算出的赋值列表为空，或 OnlyIfNewer 没有可以保护的赋值时返回 ErrEmptyDuplicateUpdate
+// 否则语句会退回到 gorm 的 OnConflict{UpdateAll: true}，更新被排除的列并丢掉 OnlyIfNewer 的条件
+func (b *insertBuilderWithValues[T]) checkDuplicateUpdate() error {
+	if len(b.duplicateUpdates) > 0 {
+		return nil
+	}
+	if b.emptyUpdate != "" {
+		return fmt.Errorf("%w: %s matched no columns", ErrEmptyDuplicateUpdate, b.emptyUpdate)
+	}
+	if b.newerField != nil {
+		return fmt.Errorf("%w: OnlyIfNewer(%s) without assignments", ErrEmptyDuplicateUpdate, b.newerField.Name())
+	}
+	return nil
+}
+
+// assignments 返回最终的 ON DUPLICATE KEY UPDATE 赋值列表，同一列只保留最后一次赋值
+func (b *insertBuilderWithValues[T]) assignments() []Assignment {
+	items := dedupeAssignments(b.duplicateUpdates)
+	if b.newerField == nil {
+		return items
+	}
+	var (
+		ret  = make([]Assignment, 0, len(items))
+		last []Assignment
+		name = b.newerField.Name()
+	)
+	for _, item := range items {
+		item.Value = clause.Expr{
+			SQL:  "IF(? > ?, ?, ?)",
+			Vars: []any{rowValue(b.newerField), b.newerField, item.Value, item.Column},
+		}
+		if item.Column.Name() == name {
+			last = append(last, item)
+		} else {
+			ret = append(ret, item)
+		}
+	}
+	return append(ret, last...)
+}
+
+// dedupeAssignments 按列去重，保留列第一次出现的位置和最后一次赋值
+func dedupeAssignments(items []Assignment) []Assignment {
+	ret := make([]Assignment, 0, len(items))
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		if i, ok := index[item.Column.Name()]; ok {
+			ret[i] = item
+			continue
+		}
+		index[item.Column.Name()] = len(ret)
+		ret = append(ret, item)
+	}
+	return ret
+}
+
 // DuplicateUpdateExpr 设置 ON DUPLICATE KEY UPDATE 使用自定义表达式
+// 与 DuplicateUpdate 等方法更新同一列时，后设置的赋值生效
 // 支持带条件的更新，如:
 //
 //	InsertInto(table).
@@ -145,12 +278,14 @@ func (b *insertBuilderWithValues[T]) DuplicateUpdateExpr(assignments ...Assignme
 }
 
 // ToSQL 生成 ON DUPLICATE KEY UPDATE 部分的 SQL 字符串（用于调试和测试）
+// 不依赖服务器版本，插入行的值始终输出为 VALUES(`col`)；
+// Exec 在 MySQL 8.0.19+ 上使用行别名 AS `new`，此时执行的语句与 ToSQL 不同
 func (b *insertBuilderWithValues[T]) ToSQL() string {
 	if len(b.duplicateUpdates) == 0 {
 		return ""
 	}
 	builder := utils.NewMemoryBuilder()
-	onConflict := onConflictWithExprs{assignments: b.duplicateUpdates}
+	onConflict := onConflictWithExprs{assignments: b.assignments()}
 	onConflict.Build(builder)
 	return builder.SQL.String()
 }
@@ -161,9 +296,52 @@ func (b *insertBuilderWithValues[T]) Exec(db IGormDB) error {
 }
 
 func (b *insertBuilderWithValues[T]) ExecWithResult(db IGormDB) (int64, error) {
-	var tx = db.Model(lo.Empty[T]())
-	addSelects(tx.Statement, tx.Statement.Distinct, b.selectColumns)
-	if b.ignore {
+	if b.fillIDs {
+		return b.execFillIDs(db)
+	}
+	tx, err := b.newTx(db)
+	if err != nil {
+		return 0, err
+	}
+
+	// 处理 ON DUPLICATE KEY UPDATE
+	if len(b.duplicateUpdates) > 0 {
+		ret, err := b.execDirect(tx, b.values, "")
+		return ret.rowsAffected, err
+	}
+
+	obs := beginExec(tx, insertOperation(b.replace, b.onDuplicateUpdate), "")
+	ret := tx.Create(b.values)
+	obs.endTx(ret)
+	return ret.RowsAffected, ret.Error
+}
+
+// insertOperation 返回插入语句对应的 Operation
+func insertOperation(replace, onDuplicateUpdate bool) Operation {
+	switch {
+	case replace:
+		return OpReplace
+	case onDuplicateUpdate:
+		return OpUpsert
+	default:
+		return OpInsert
+	}
+}
+
+// newTx 创建本次插入使用的会话，并设置插入列与 INSERT 修饰子句
+func (b *insertBuilderWithValues[T]) newTx(db IGormDB) (*GormDB, error) {
+	if err := b.checkDuplicateUpdate(); err != nil {
+		return nil, err
+	}
+	var tx = withContext(db, b.ctx).Model(lo.Empty[T]())
+	if err := checkInsertColumns(tx.Statement, b.selectColumns); err != nil {
+		return nil, err
+	}
+	setTags(tx.Statement, b.tags)
+	tx = selectInsertColumns(tx, b.selectColumns)
+	if b.replace {
+		tx = tx.Clauses(replaceClause{})
+	} else if b.ignore {
 		tx = tx.Clauses(clause.Insert{
 			Modifier: "IGNORE",
 		})
@@ -172,79 +350,116 @@ func (b *insertBuilderWithValues[T]) ExecWithResult(db IGormDB) (int64, error) {
 			UpdateAll: true,
 		})
 	}
+	return tx, nil
+}
 
-	// 处理 ON DUPLICATE KEY UPDATE
-	if len(b.duplicateUpdates) > 0 {
-		// 使用 Recorder 捕获开始时间
-		config := *tx.Config
-		currentLogger, newLogger := config.Logger, logger.Recorder.New()
-		config.Logger = newLogger
-		tx.Config = &config
-
-		tx.Statement.Dest = b.values
-		tx.Statement.SQL.Reset()
-		tx.Statement.Vars = nil
-
-		tx = processerExec(createClauses, tx)
-		if tx.Error != nil {
-			return 0, tx.Error
-		}
-		stmt := tx.Statement
-
-		stmt.SQL.Grow(180)
-		stmt.AddClauseIfNotExists(clause.Insert{})
-
-		// 添加 VALUES 子句
-		valuesClause := callbacks.ConvertToCreateValues(stmt)
-		stmt.AddClause(valuesClause)
-
-		// 替换 OnConflict 子句，使用自定义表达式
-		if v, ok := stmt.Clauses[clause.OnConflict{}.Name()]; ok {
-			if _, ok := v.Expression.(clause.OnConflict); ok {
-				customOnConflict := onConflictWithExprs{
-					assignments: b.duplicateUpdates,
-				}
-				v.Name = "" // 清空名称，避免输出 "ON CONFLICT" 前缀
-				v.Expression = customOnConflict
-				stmt.Clauses[clause.OnConflict{}.Name()] = v
-			}
-		}
-
-		stmt.Build(createClauses...)
+// directResult execDirect 的执行结果
+type directResult struct {
+	rowsAffected int64
+	lastInsertID int64
+	returnedIDs  []int64
+}
 
-		// 设置 newLogger.SQL，用于日志输出
-		newLogger.SQL = tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...)
+// execDirect 构建 INSERT 语句并直接通过 ConnPool 执行，不经过 gorm 的 Create 回调
+// 因此通过 db.Callback() 注册的回调和模型的 BeforeCreate/AfterCreate 等钩子方法不会执行；
+// ExecHook（如 Metrics、otelgsql）、Commenter 和 logger 在这里显式调用，与其他执行路径一致
+// returning 不为空时追加 RETURNING 子句并返回该列的值；extra 追加到 ON DUPLICATE KEY UPDATE 的末尾
+func (b *insertBuilderWithValues[T]) execDirect(tx *GormDB, values *[]T, returning string, extra ...Assignment) (directResult, error) {
+	var ret directResult
+	// 语句在 beginExec 之前构建，需要提前开始记录参数对应的列
+	trackBoundColumns(tx)
+
+	// 使用 Recorder 捕获开始时间
+	config := *tx.Config
+	currentLogger, newLogger := config.Logger, logger.Recorder.New()
+	config.Logger = newLogger
+	tx.Config = &config
+
+	tx, err := b.buildDirect(tx, values, returning, extra...)
+	if err != nil {
+		return ret, err
+	}
+	stmt := tx.Statement
 
-		// 直接执行已构建的 SQL
-		result, err := tx.Statement.ConnPool.ExecContext(
+	// 直接执行已构建的 SQL
+	obs := beginExec(tx, insertOperation(b.replace, b.onDuplicateUpdate), stmt.Table)
+	if returning != "" {
+		ret.returnedIDs, err = queryIDs(tx.Statement)
+		ret.rowsAffected = int64(len(ret.returnedIDs))
+	} else {
+		var result sql.Result
+		result, err = tx.Statement.ConnPool.ExecContext(
 			tx.Statement.Context,
 			tx.Statement.SQL.String(),
 			tx.Statement.Vars...,
 		)
-		rowsAffected := int64(0)
 		if result != nil {
-			rowsAffected, _ = result.RowsAffected()
+			ret.rowsAffected, _ = result.RowsAffected()
+			ret.lastInsertID, _ = result.LastInsertId()
 		}
+	}
+	obs.end(stmt, ret.rowsAffected, err)
+
+	// 输出 SQL 日志
+	currentLogger.Trace(tx.Statement.Context, newLogger.BeginAt, func() (string, int64) {
+		return explainSQL(tx.Statement.Context, currentLogger, tx.Dialector, stmt.SQL.String(), stmt.Vars), ret.rowsAffected
+	}, err)
+	tx.Logger = currentLogger
+
+	if err != nil {
+		return directResult{}, err
+	}
+	return ret, nil
+}
+
+// buildDirect 构建 execDirect 执行的 INSERT 语句
+func (b *insertBuilderWithValues[T]) buildDirect(tx *GormDB, values *[]T, returning string, extra ...Assignment) (*GormDB, error) {
+	tx.Statement.Dest = values
+	tx.Statement.SQL.Reset()
+	tx.Statement.Vars = nil
+
+	tx = processerExec(createClauses, tx)
+	if tx.Error != nil {
+		return tx, tx.Error
+	}
+	stmt := tx.Statement
+	rowAlias := rowAliasFor(tx, b.rowAlias)
+	stmt.Settings.Store(clauses2.RowAliasKey, rowAlias)
+
+	stmt.SQL.Grow(180)
+	stmt.AddClauseIfNotExists(clause.Insert{})
 
-		// 输出 SQL 日志
-		currentLogger.Trace(tx.Statement.Context, newLogger.BeginAt, func() (string, int64) {
-			return newLogger.SQL, rowsAffected
-		}, err)
-		tx.Logger = currentLogger
+	// 添加 VALUES 子句
+	valuesClause := callbacks.ConvertToCreateValues(stmt)
+	stmt.AddClause(valuesClause)
 
-		if err != nil {
-			return 0, err
+	// 替换 OnConflict 子句，使用自定义表达式
+	if v, ok := stmt.Clauses[clause.OnConflict{}.Name()]; ok {
+		if _, ok := v.Expression.(clause.OnConflict); ok {
+			customOnConflict := onConflictWithExprs{
+				assignments: append(b.assignments(), extra...),
+				rowAlias:    rowAlias,
+			}
+			v.Name = "" // 清空名称，避免输出 "ON CONFLICT" 前缀
+			v.Expression = customOnConflict
+			stmt.Clauses[clause.OnConflict{}.Name()] = v
 		}
-		return rowsAffected, nil
 	}
 
-	ret := tx.Create(b.values)
-	return ret.RowsAffected, ret.Error
+	buildClauses := createClauses
+	if returning != "" {
+		stmt.AddClause(clause.Returning{Columns: []clause.Column{{Name: returning}}})
+		buildClauses = append(slices.Clone(createClauses), "RETURNING")
+	}
+	stmt.BuildClauses = buildClauses
+	stmt.Build(buildClauses...)
+	return tx, nil
 }
 
 // onConflictWithExprs 自定义的 OnConflict 表达式，支持复杂的更新逻辑
 type onConflictWithExprs struct {
 	assignments []Assignment
+	rowAlias    clauses2.RowAlias
 }
 
 func (o onConflictWithExprs) Name() string {
@@ -252,6 +467,11 @@ func (o onConflictWithExprs) Name() string {
 }
 
 func (o onConflictWithExprs) Build(builder clause.Builder) {
+	if o.rowAlias.Name != "" && !o.rowAlias.Legacy {
+		builder.WriteString("AS ")
+		builder.WriteQuoted(o.rowAlias.Name)
+		builder.WriteByte(' ')
+	}
 	builder.WriteString("ON DUPLICATE KEY UPDATE ")
 	for idx, assignment := range o.assignments {
 		if idx > 0 {
@@ -259,7 +479,7 @@ func (o onConflictWithExprs) Build(builder clause.Builder) {
 		}
 		builder.WriteQuoted(assignment.Column.Name())
 		builder.WriteByte('=')
-		assignment.Value.Build(builder)
+		clauses2.BindVar(builder, columnName(assignment.Column), assignment.Value)
 	}
 }
 
@@ -268,6 +488,56 @@ func (o onConflictWithExprs) MergeClause(c *clause.Clause) {
 	c.Expression = o
 }
 
+// withContext ctx 不为空时返回使用该 context 的会话
+func withContext(db IGormDB, ctx context.Context) IGormDB {
+	if ctx == nil {
+		return db
+	}
+	return db.Session(&Session{Context: ctx})
+}
+
+// columnName 返回字段的列名，不带别名
+func columnName(f field.IField) string {
+	if c, ok := f.(interface{ ColumnName() string }); ok {
+		return c.ColumnName()
+	}
+	return f.Name()
+}
+
+// tableFields 返回表结构中的所有字段，优先使用 AllFields()
+func tableFields(table any) []field.IField {
+	if v, ok := table.(interface{ AllFields() field.BaseFields }); ok {
+		return v.AllFields()
+	}
+	rv := reflect.Indirect(reflect.ValueOf(table))
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("[tableFields] unsupported table type %T", table))
+	}
+	var ret []field.IField
+	for i := 0; i < rv.NumField(); i++ {
+		if !rv.Type().Field(i).IsExported() {
+			continue
+		}
+		if v, ok := rv.Field(i).Interface().(field.IField); ok {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func hasFieldFlag(f field.IField, flag field.FieldFlag) bool {
+	v, ok := f.(interface {
+		HasFlag(flag field.FieldFlag) bool
+	})
+	return ok && v.HasFlag(flag)
+}
+
+func isKeyField(f field.IField) bool {
+	return hasFieldFlag(f, field.FlagPrimaryKey) ||
+		hasFieldFlag(f, field.FlagUniqueIndex) ||
+		hasFieldFlag(f, field.FlagAutoIncrement)
+}
+
 func processerExec(pClauses []string, db *GormDB) *GormDB {
 	// call scopes
 	//for len(db.Statement.scopes) > 0 {
@@ -352,29 +622,66 @@ func processerExec(pClauses []string, db *GormDB) *GormDB {
 
 //////////////////////// select /////////////////////////////
 
+// Select 使用 SELECT 查询结果插入，执行前校验目标列与 SELECT 字段的数量和类型
 func (b *InsertBuilder[T]) Select(q interface{ ToExpr() clause.Expr }) *insertBuilderWithSelect[T] {
-	return &insertBuilderWithSelect[T]{
-		selectColumns: b.selectColumns,
-		ignore:        b.ignore,
+	return newInsertBuilderWithSelect[T](b.selectColumns, q, b.ignore, false)
+}
+
+func newInsertBuilderWithSelect[T any](columns []field.IField, q interface{ ToExpr() clause.Expr }, ignore, replace bool) *insertBuilderWithSelect[T] {
+	ret := &insertBuilderWithSelect[T]{
+		selectColumns: columns,
+		ignore:        ignore,
+		replace:       replace,
 		query:         q.ToExpr(),
 	}
+	if v, ok := q.(selectFieldsProvider); ok {
+		ret.queryFields = v.selectFields()
+	}
+	return ret
 }
 
 type insertBuilderWithSelect[T any] struct {
 	ignore            bool
+	replace           bool
 	onDuplicateUpdate bool
 	duplicateUpdates  []Assignment
 	query             clause.Expr
+	queryFields       []field.IField
 	selectColumns     []field.IField
+	rowAlias          string
+	ctx               context.Context
+	tags              map[string]string
+}
+
+// WithContext 设置执行时使用的 context
+func (b *insertBuilderWithSelect[T]) WithContext(ctx context.Context) *insertBuilderWithSelect[T] {
+	b.ctx = ctx
+	return b
+}
+
+// Tag 设置 SQL 注释标签，见 Commenter
+func (b *insertBuilderWithSelect[T]) Tag(key, value string) *insertBuilderWithSelect[T] {
+	b.tags = addTag(b.tags, key, value)
+	return b
 }
 
+// As 设置 SELECT 结果的别名，默认为 DefaultRowAlias
+// 需要与 RowAlias(t, alias) 使用相同的名字
+func (b *insertBuilderWithSelect[T]) As(alias string) *insertBuilderWithSelect[T] {
+	b.rowAlias = alias
+	return b
+}
+
+// DuplicateUpdate 使用插入行的值更新指定列
+// MySQL 8.0.19+ 将 SELECT 包装为派生表 SELECT * FROM (...) AS `new` (列...)，输出 `col`=`new`.`col`；
+// 其他版本输出 `col`=VALUES(`col`)
 func (b *insertBuilderWithSelect[T]) DuplicateUpdate(columns ...field.IField) *insertBuilderWithSelect[T] {
 	b.onDuplicateUpdate = true
-	// 将列转换为 Assignment，使用 Values 引用插入行的值
+	// 将列转换为 Assignment，使用行别名引用插入行的值
 	for _, col := range columns {
 		b.duplicateUpdates = append(b.duplicateUpdates, Assignment{
 			Column: col,
-			Value:  clause.Expr{SQL: "VALUES(?)", Vars: []any{col}},
+			Value:  rowValue(col),
 		})
 	}
 	return b
@@ -393,13 +700,27 @@ func (b *insertBuilderWithSelect[T]) Exec(db IGormDB) error {
 }
 
 func (b *insertBuilderWithSelect[T]) ExecWithResult(db IGormDB) (int64, error) {
-	var tx = db.Model(lo.Empty[T]())
-	if len(b.selectColumns) > 0 {
-		tx = tx.Select(lo.Map(b.selectColumns, func(item field.IField, index int) string {
-			return item.Name()
-		}))
+	tx, def, err := b.build(db)
+	if err != nil {
+		return 0, err
+	}
+	obs := beginExec(tx, insertOperation(b.replace, b.onDuplicateUpdate), tx.Statement.Table)
+	ret := tx.Create(def)
+	obs.endTx(ret)
+	return ret.RowsAffected, ret.Error
+}
+
+// build 构建 INSERT ... SELECT 语句，返回的 def 为执行时传给 Create 的占位模型
+func (b *insertBuilderWithSelect[T]) build(db IGormDB) (*GormDB, *[]T, error) {
+	var tx = withContext(db, b.ctx).Model(lo.Empty[T]())
+	if err := checkInsertColumns(tx.Statement, b.selectColumns); err != nil {
+		return nil, nil, err
 	}
-	if b.ignore {
+	setTags(tx.Statement, b.tags)
+	tx = selectInsertColumns(tx, b.selectColumns)
+	if b.replace {
+		tx = tx.Clauses(replaceClause{})
+	} else if b.ignore {
 		tx = tx.Clauses(clause.Insert{
 			Modifier: "IGNORE",
 		})
@@ -417,7 +738,7 @@ func (b *insertBuilderWithSelect[T]) ExecWithResult(db IGormDB) (int64, error) {
 
 	tx = processerExec(createClauses, tx)
 	if tx.Error != nil {
-		return 0, tx.Error
+		return nil, nil, tx.Error
 	}
 	stmt := tx.Statement
 
@@ -425,44 +746,62 @@ func (b *insertBuilderWithSelect[T]) ExecWithResult(db IGormDB) (int64, error) {
 	stmt.AddClauseIfNotExists(clause.Insert{})
 
 	values := valuesWhere{Columns: make([]clause.Column, 0, len(stmt.Schema.DBNames))}
-	selectColumns, restricted := stmt.SelectAndOmitColumns(true, false)
-	for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
-		if v, ok := selectColumns[field.DBName]; (ok && v) || (!ok && !restricted) {
-			values.Columns = append(values.Columns, clause.Column{Name: field.DBName})
+	if len(b.selectColumns) > 0 {
+		// 指定了目标列时按调用方给出的顺序输出，与 SELECT 字段一一对应
+		for _, col := range b.selectColumns {
+			values.Columns = append(values.Columns, clause.Column{Name: col.Name()})
 		}
-	}
-	for _, dbName := range stmt.Schema.DBNames {
-		if field := stmt.Schema.FieldsByDBName[dbName]; !field.HasDefaultValue || field.DefaultValueInterface != nil {
-			if v, ok := selectColumns[dbName]; (ok && v) || (!ok && (!restricted || field.AutoCreateTime > 0 || field.AutoUpdateTime > 0)) {
-				values.Columns = append(values.Columns, clause.Column{Name: dbName})
+	} else {
+		selectColumns, restricted := stmt.SelectAndOmitColumns(true, false)
+		for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
+			if v, ok := selectColumns[field.DBName]; (ok && v) || (!ok && !restricted) {
+				values.Columns = append(values.Columns, clause.Column{Name: field.DBName})
 			}
 		}
+		for _, dbName := range stmt.Schema.DBNames {
+			if field := stmt.Schema.FieldsByDBName[dbName]; !field.HasDefaultValue || field.DefaultValueInterface != nil {
+				if v, ok := selectColumns[dbName]; (ok && v) || (!ok && (!restricted || field.AutoCreateTime > 0 || field.AutoUpdateTime > 0)) {
+					values.Columns = append(values.Columns, clause.Column{Name: dbName})
+				}
+			}
+		}
+	}
+	if err := checkSelectColumns(b.selectColumns, len(values.Columns), b.queryFields); err != nil {
+		return nil, nil, err
 	}
 	values.query = b.query
-	stmt.AddClause(values)
 
 	// 处理 ON DUPLICATE KEY UPDATE
 	if len(b.duplicateUpdates) > 0 {
 		if v, ok := stmt.Clauses[clause.OnConflict{}.Name()]; ok {
 			if _, ok := v.Expression.(clause.OnConflict); ok {
+				// INSERT ... SELECT 不支持行别名，通过派生表的别名引用插入行
+				rowAlias := rowAliasFor(tx, b.rowAlias)
+				stmt.Settings.Store(clauses2.RowAliasKey, rowAlias)
+				if !rowAlias.Legacy {
+					values.alias = rowAlias.Name
+				}
 				customOnConflict := onConflictWithExprs{
-					assignments: b.duplicateUpdates,
+					assignments: dedupeAssignments(b.duplicateUpdates),
 				}
+				v.Name = "" // 清空名称，避免输出 "ON CONFLICT" 前缀
 				v.Expression = customOnConflict
 				stmt.Clauses[clause.OnConflict{}.Name()] = v
 			}
 		}
 	}
 
-	stmt.Build(createClauses...)
+	stmt.AddClause(values)
 
-	ret := tx.Create(&def)
-	return ret.RowsAffected, ret.Error
+	stmt.Build(createClauses...)
+	return tx, &def, nil
 }
 
 type valuesWhere struct {
 	Columns []clause.Column
 	query   clause.Expression
+	// alias 不为空时将查询包装为派生表 SELECT * FROM (query) AS alias (Columns...)
+	alias string
 }
 
 // Name from clause name
@@ -483,7 +822,22 @@ func (values valuesWhere) Build(builder clause.Builder) {
 		}
 		writer.WriteByte(')')
 		writer.WriteByte(' ')
+		if values.alias == "" {
+			values.query.Build(builder)
+			return
+		}
+		writer.WriteString("SELECT * FROM (")
 		values.query.Build(builder)
+		writer.WriteString(") AS ")
+		writer.WriteQuoted(values.alias)
+		writer.WriteString(" (")
+		for idx, column := range values.Columns {
+			if idx > 0 {
+				writer.WriteByte(',')
+			}
+			writer.WriteQuoted(column)
+		}
+		writer.WriteByte(')')
 	} else {
 		writer.WriteString("DEFAULT VALUES")
 	}
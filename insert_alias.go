@@ -0,0 +1,112 @@
+package gsql
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/donutnomad/gsql/field"
+	"github.com/donutnomad/gsql/internal/clauses2"
+	"github.com/samber/lo"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// DefaultRowAlias INSERT ... AS new ON DUPLICATE KEY UPDATE 的默认行别名
+const DefaultRowAlias = "new"
+
+// RowAlias 返回引用插入行的类型化表结构，用于 ON DUPLICATE KEY UPDATE
+// MySQL 8.0.19+ 输出 `new`.`col`，低版本和 MariaDB 自动回退为 VALUES(`col`)
+// 示例:
+//
+//	n := gsql.RowAlias(t)
+//	gsql.InsertInto(t).
+//	    Value(row).
+//	    DuplicateUpdateExpr(
+//	        gsql.Set(t.Value, gsql.IF(n.Version.GtF(t.Version), n.Value.Expr(), t.Value.Expr())),
+//	    )
+//	// INSERT INTO ... VALUES (...) AS `new`
+//	// ON DUPLICATE KEY UPDATE `value`=CASE WHEN `new`.`version` > `t`.`version` THEN `new`.`value` ELSE `t`.`value` END
+func RowAlias[S any](schema S, alias ...string) S {
+	name := lo.FirstOr(alias, DefaultRowAlias)
+	var ret = schema
+	if v, ok := any(&ret).(interface{ WithTable(tableName string) }); ok {
+		v.WithTable(name)
+	} else {
+		withTableFields(&ret, name)
+	}
+	markRowAlias(&ret)
+	return ret
+}
+
+// markRowAlias 将 ptr 指向的表结构中的列标记为行别名列，只有这些列会在不支持行别名时回退为 VALUES(`col`)
+// 与 withTableFields 相同，只处理导出的字段；WithTable 为每个字段创建了新的列，不会影响原表结构
+func markRowAlias(ptr any) {
+	rv := reflect.Indirect(reflect.ValueOf(ptr))
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < rv.NumField(); i++ {
+		if !rv.Type().Field(i).IsExported() {
+			continue
+		}
+		if q := clauses2.ColumnOf(rv.Field(i).Interface()); q != nil {
+			q.RowAlias = true
+		}
+	}
+}
+
+// rowValue 返回引用插入行中 col 列的值
+func rowValue(col field.IField) clauses2.RowValue {
+	if q := clauses2.ColumnOf(col); q != nil {
+		return q.RowValue()
+	}
+	return clauses2.RowValue{ColumnName: col.Name()}
+}
+
+// supportsRowAlias 根据配置的服务器版本判断是否支持 INSERT ... AS alias 语法
+// 仅 MySQL 8.0.19 及以上版本支持，MariaDB 不支持；无法识别版本时返回 false
+func supportsRowAlias(dialector gorm.Dialector) bool {
+	version, ok := serverVersion(dialector)
+	if !ok || version == "" || strings.Contains(version, "MariaDB") {
+		return false
+	}
+	return versionAtLeast(version, 8, 0, 19)
+}
+
+// serverVersion 返回 MySQL 方言配置的服务器版本，非 MySQL 方言返回 false
+func serverVersion(dialector gorm.Dialector) (string, bool) {
+	d, ok := dialector.(*mysql.Dialector)
+	if !ok || d.Config == nil {
+		return "", false
+	}
+	return d.ServerVersion, true
+}
+
+// versionAtLeast 判断形如 8.0.36 或 10.11.2-MariaDB 的版本号是否不低于 major.minor.patch
+func versionAtLeast(version string, major, minor, patch int) bool {
+	if idx := strings.IndexFunc(version, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	}); idx >= 0 {
+		version = version[:idx]
+	}
+	var parts [3]int
+	for i, s := range strings.SplitN(version, ".", 3) {
+		parts[i], _ = strconv.Atoi(s)
+	}
+	if parts[0] != major {
+		return parts[0] > major
+	}
+	if parts[1] != minor {
+		return parts[1] > minor
+	}
+	return parts[2] >= patch
+}
+
+// rowAliasFor 计算当前语句使用的行别名及渲染方式
+func rowAliasFor(db *GormDB, name string) clauses2.RowAlias {
+	return clauses2.RowAlias{
+		Name:   lo.Ternary(name == "", DefaultRowAlias, name),
+		Legacy: !supportsRowAlias(db.Dialector),
+	}
+}
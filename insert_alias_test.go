@@ -0,0 +1,239 @@
+package gsql_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// capturePool 记录执行的 SQL，不连接真实数据库
+type capturePool struct {
+	sqls []string
+	// lastInsertID 每次执行返回的 LastInsertId，为 0 时返回 1
+	lastInsertID int64
+	// ctx 最近一次执行时传入的 context
+	ctx context.Context
+	// err 不为 nil 时每次执行都返回该错误，设置了 failAt 时只有第 failAt 次执行返回
+	err    error
+	failAt int
+	execs  int
+}
+
+func (p *capturePool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, nil
+}
+
+func (p *capturePool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	p.sqls = append(p.sqls, query)
+	p.ctx = ctx
+	p.execs++
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if p.err != nil && (p.failAt == 0 || p.failAt == p.execs) {
+		return nil, p.err
+	}
+	return driverResult{lastInsertID: max(p.lastInsertID, 1), rowsAffected: 1}, nil
+}
+
+func (p *capturePool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	p.sqls = append(p.sqls, query)
+	p.ctx = ctx
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (p *capturePool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	p.sqls = append(p.sqls, query)
+	return nil
+}
+
+type driverResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r driverResult) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r driverResult) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+func openCaptureDB(t *testing.T, serverVersion string) (*gorm.DB, *capturePool) {
+	t.Helper()
+	pool := &capturePool{}
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      pool,
+		ServerVersion:             serverVersion,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	return db, pool
+}
+
+func TestRowAlias_DuplicateUpdate(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	row := MessageConsumerProgress{ID: 1, ConsumerGroup: "g", GenerationID: 2}
+
+	cases := []struct {
+		version string
+		expects []string
+	}{
+		{
+			version: "8.0.36",
+			expects: []string{
+				"AS `new` ON DUPLICATE KEY UPDATE ",
+				"`last_consumed_message_id`=CASE WHEN (`new`.`generation_id` >= `message_consumer_progress`.`generation_id`) THEN `new`.`last_consumed_message_id` ELSE `message_consumer_progress`.`last_consumed_message_id` END",
+				"`generation_id`=`new`.`generation_id`",
+			},
+		},
+		{
+			version: "8.0.18",
+			expects: []string{
+				") ON DUPLICATE KEY UPDATE ",
+				"`last_consumed_message_id`=CASE WHEN (VALUES(`generation_id`) >= `message_consumer_progress`.`generation_id`) THEN VALUES(`last_consumed_message_id`) ELSE `message_consumer_progress`.`last_consumed_message_id` END",
+				"`generation_id`=VALUES(`message_consumer_progress`.`generation_id`)",
+			},
+		},
+		{
+			version: "10.11.2-MariaDB",
+			expects: []string{
+				") ON DUPLICATE KEY UPDATE ",
+				"`generation_id`=VALUES(`message_consumer_progress`.`generation_id`)",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			db, pool := openCaptureDB(t, tc.version)
+			n := gsql.RowAlias(table)
+
+			err := gsql.InsertInto(table).
+				Value(row).
+				DuplicateUpdateExpr(
+					gsql.Set(table.LastConsumedMessageID, gsql.IF(
+						n.GenerationID.GteF(table.GenerationID),
+						n.LastConsumedMessageID.Expr(),
+						table.LastConsumedMessageID.Expr(),
+					)),
+				).
+				DuplicateUpdate(table.GenerationID).
+				Exec(db)
+			if err != nil {
+				t.Fatalf("exec: %v", err)
+			}
+			if len(pool.sqls) != 1 {
+				t.Fatalf("expected 1 statement, got %d", len(pool.sqls))
+			}
+			sql := pool.sqls[0]
+			t.Log(sql)
+			for _, expect := range tc.expects {
+				if !strings.Contains(sql, expect) {
+					t.Errorf("期望包含 %q，实际: %s", expect, sql)
+				}
+			}
+		})
+	}
+}
+
+func TestRowAlias_CustomName(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, pool := openCaptureDB(t, "8.4.0")
+
+	n := gsql.RowAlias(table, "incoming")
+	err := gsql.InsertInto(table).
+		Value(MessageConsumerProgress{ID: 1}).
+		As("incoming").
+		DuplicateUpdateExpr(gsql.Set(table.GenerationID, n.GenerationID)).
+		Exec(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	sql := pool.sqls[0]
+	if !strings.Contains(sql, "AS `incoming` ON DUPLICATE KEY UPDATE `generation_id`=`incoming`.`generation_id`") {
+		t.Errorf("unexpected sql: %s", sql)
+	}
+}
+
+func TestRowAlias_ApplyValuesAndSelect(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	query := gsql.SelectG[MessageConsumerProgress](table.ID, table.GenerationID).From(gsql.TN("staging"))
+
+	cases := []struct {
+		version string
+		values  string
+		selects string
+	}{
+		{
+			version: "8.0.36",
+			values:  "AS `new` ON DUPLICATE KEY UPDATE `generation_id`=`new`.`generation_id`",
+			selects: "(`id`,`generation_id`) SELECT * FROM (SELECT `message_consumer_progress`.`id`, `message_consumer_progress`.`generation_id` FROM `staging`) AS `new` (`id`,`generation_id`) ON DUPLICATE KEY UPDATE `generation_id`=`new`.`generation_id`",
+		},
+		{
+			version: "8.0.18",
+			values:  ") ON DUPLICATE KEY UPDATE `generation_id`=VALUES(`message_consumer_progress`.`generation_id`)",
+			selects: "(`id`,`generation_id`) SELECT `message_consumer_progress`.`id`, `message_consumer_progress`.`generation_id` FROM `staging` ON DUPLICATE KEY UPDATE `generation_id`=VALUES(`message_consumer_progress`.`generation_id`)",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.version, func(t *testing.T) {
+			db, pool := openCaptureDB(t, tc.version)
+			// Apply(gsql.VALUES) 与 DuplicateUpdate 一样按版本输出
+			err := gsql.InsertInto(table).
+				Value(MessageConsumerProgress{ID: 1}).
+				DuplicateUpdateExpr(gsql.Set(table.GenerationID, table.GenerationID.Apply(gsql.VALUES))).
+				Exec(db)
+			if err != nil {
+				t.Fatalf("exec: %v", err)
+			}
+			err = gsql.InsertInto(table, table.ID, table.GenerationID).
+				Select(query).
+				DuplicateUpdate(table.GenerationID).
+				Exec(db)
+			if err != nil {
+				t.Fatalf("exec select: %v", err)
+			}
+			if len(pool.sqls) != 2 {
+				t.Fatalf("expected 2 statements, got %d", len(pool.sqls))
+			}
+			if !strings.Contains(pool.sqls[0], tc.values) {
+				t.Errorf("期望包含 %q，实际: %s", tc.values, pool.sqls[0])
+			}
+			if !strings.Contains(pool.sqls[1], tc.selects) {
+				t.Errorf("期望包含 %q，实际: %s", tc.selects, pool.sqls[1])
+			}
+		})
+	}
+}
+
+// 名为 new 的真实表不是行别名，不会被改写为 VALUES()
+func TestRowAlias_TableNamedNew(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	other := gsql.IntFieldOf[int64]("new", "generation_id")
+
+	for _, version := range []string{"8.0.36", "8.0.18"} {
+		t.Run(version, func(t *testing.T) {
+			db, pool := openCaptureDB(t, version)
+			err := gsql.InsertInto(table).
+				Value(MessageConsumerProgress{ID: 1}).
+				DuplicateUpdateExpr(gsql.Set(table.GenerationID, other)).
+				Exec(db)
+			if err != nil {
+				t.Fatalf("exec: %v", err)
+			}
+			if !strings.Contains(pool.sqls[0], "`generation_id`=`new`.`generation_id`") {
+				t.Errorf("unexpected sql: %s", pool.sqls[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package gsql
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
+	"github.com/samber/lo"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInsertColumnMismatch INSERT/REPLACE 的目标列与插入数据不匹配
+var ErrInsertColumnMismatch = errors.New("insert column mismatch")
+
+// selectFieldsProvider 提供 SELECT 字段列表，用于 INSERT ... SELECT 的列映射校验
+type selectFieldsProvider interface {
+	selectFields() []field.IField
+}
+
+// replaceClause 将 INSERT 子句输出为 REPLACE INTO
+type replaceClause struct {
+	clause.Insert
+}
+
+func (r replaceClause) MergeClause(c *clause.Clause) {
+	r.Insert.MergeClause(c)
+	c.Name = "REPLACE"
+}
+
+// selectInsertColumns 限定插入的列为调用方指定的列
+func selectInsertColumns(tx *GormDB, columns []field.IField) *GormDB {
+	if len(columns) == 0 {
+		return tx
+	}
+	return tx.Select(lo.Map(columns, func(item field.IField, index int) string {
+		return item.Name()
+	}))
+}
+
+// checkInsertColumns 校验指定的目标列都存在于表结构中，且列的类型族与模型字段的数据类型兼容
+func checkInsertColumns(stmt *Statement, columns []field.IField) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	if stmt.Schema == nil {
+		if err := stmt.Parse(stmt.Model); err != nil {
+			return nil // 由后续的执行流程报告解析错误
+		}
+	}
+	for _, col := range columns {
+		f := stmt.Schema.LookUpField(col.Name())
+		if f == nil {
+			return fmt.Errorf("%w: unknown column `%s` in table `%s`", ErrInsertColumnMismatch, col.Name(), stmt.Schema.Table)
+		}
+		if family := fieldFamily(col); !dataTypeCompatible(f.DataType, family) {
+			return fmt.Errorf("%w: column `%s` (%s) does not match model field %s (%s)", ErrInsertColumnMismatch, col.Name(), family, f.Name, f.DataType)
+		}
+	}
+	return nil
+}
+
+// checkSelectColumns 校验 INSERT ... SELECT 的列数以及目标列与 SELECT 字段的类型族是否兼容
+// SELECT 字段未知（如 SELECT *）时跳过校验；columns 为空时只校验列数
+func checkSelectColumns(columns []field.IField, targetCount int, selects []field.IField) error {
+	if len(selects) == 0 || slices.ContainsFunc(selects, func(f field.IField) bool {
+		return f.Name() == "*"
+	}) {
+		return nil
+	}
+	if targetCount != len(selects) {
+		return fmt.Errorf("%w: %d target columns but SELECT returns %d fields", ErrInsertColumnMismatch, targetCount, len(selects))
+	}
+	for i, col := range columns {
+		target, source := fieldFamily(col), fieldFamily(selects[i])
+		if !familyCompatible(target, source) {
+			return fmt.Errorf("%w: column `%s` (%s) cannot accept SELECT field #%d (%s)", ErrInsertColumnMismatch, col.Name(), target, i+1, source)
+		}
+	}
+	return nil
+}
+
+// fieldFamily 返回类型化字段所属的类型族，如 IntField[int64] 和 IntExpr[int] 均属于 Int
+// 未实现 field.ITypedField 的字段（别名表达式、字面量等）返回空字符串
+func fieldFamily(f field.IField) string {
+	if typed, ok := f.(field.ITypedField); ok {
+		return typed.TypeFamily()
+	}
+	return ""
+}
+
+// compatibleFamilies 目标列类型族 -> 可接受的来源类型族
+var compatibleFamilies = map[string][]string{
+	"Int":      {"Int", "Bool"},
+	"Float":    {"Int", "Float", "Decimal"},
+	"Decimal":  {"Int", "Float", "Decimal"},
+	"String":   {"String", "Enum"},
+	"DateTime": {"DateTime", "Date"},
+	"Date":     {"Date", "DateTime"},
+	"Time":     {"Time"},
+	"Year":     {"Year", "Int"},
+	"Json":     {"Json", "String"},
+	"Geometry": {"Geometry"},
+	"UUID":     {"UUID"},
+	"Bool":     {"Bool", "Int"},
+	"Enum":     {"Enum", "String"},
+}
+
+func familyCompatible(target, source string) bool {
+	if target == "" || source == "" {
+		return true
+	}
+	return slices.Contains(compatibleFamilies[target], source)
+}
+
+// dataTypeFamilies 模型字段的数据类型 -> 可对应的列类型族
+var dataTypeFamilies = map[schema.DataType][]string{
+	schema.Bool:   {"Bool", "Int"},
+	schema.Int:    {"Int", "Bool", "Year", "Enum"},
+	schema.Uint:   {"Int", "Bool", "Year", "Enum"},
+	schema.Float:  {"Float", "Decimal"},
+	schema.String: {"String", "Enum", "Json", "Decimal", "UUID", "DateTime", "Date", "Time", "Year"},
+	schema.Time:   {"DateTime", "Date", "Time"},
+	schema.Bytes:  {"String", "Json", "UUID", "Geometry"},
+}
+
+// dataTypeCompatible 自定义数据类型（实现 Valuer/GormDataType 的类型）无法判断，视为兼容
+func dataTypeCompatible(dataType schema.DataType, family string) bool {
+	families, ok := dataTypeFamilies[dataType]
+	if !ok || family == "" {
+		return true
+	}
+	return slices.Contains(families, family)
+}
@@ -0,0 +1,208 @@
+package gsql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
+	"github.com/samber/lo"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ErrNoAutoIncrementField 表结构中没有可写回生成 ID 的自增字段
+var ErrNoAutoIncrementField = errors.New("no auto increment field")
+
+// FillIDs 执行后将数据库生成的自增 ID 写回 values 中带 FlagAutoIncrement 标志的字段
+// 没有字段带该标志时使用 gorm 识别的自增主键
+//   - 支持 RETURNING 的数据库（MariaDB 10.5+、PostgreSQL、SQLite）上的普通 INSERT 追加 RETURNING 子句获取 ID；
+//     RETURNING 返回的行顺序没有保证（SQLite 明确说明顺序任意，PostgreSQL 也不保证与 VALUES 一致），
+//     只有单行时一条语句执行，多行时在事务中逐行执行，每条语句返回该行的 ID；
+//     INSERT IGNORE 和 ON DUPLICATE KEY UPDATE 被忽略或更新的行不一定返回 ID，与 MySQL 相同逐行执行
+//   - MySQL 和 MariaDB 上普通 INSERT 且所有行都未设置 ID 时，使用 LAST_INSERT_ID 加连续分配计算每行的 ID；
+//     步长取字段的 gorm 标签 autoIncrementIncrement（默认 1），不读取服务端的 auto_increment_increment，
+//     服务端修改了步长时需要在标签中设置相同的值
+//   - INSERT IGNORE、ON DUPLICATE KEY UPDATE 或 MySQL 上部分行已设置 ID 时无法推算，改为在事务中逐行执行，
+//     任一行失败时回滚整批，已在事务中时使用 SAVEPOINT；
+//     upsert 追加 `id`=LAST_INSERT_ID(`id`)，已存在的行写回原有 ID，已设置 ID 和被忽略的行保持不变
+//   - 语句不经过 gorm 的 Create 回调执行，模型的 BeforeCreate/AfterCreate 等钩子方法不会被调用；
+//     ExecHook 和 logger 对每条执行的语句都会调用
+//
+// 示例:
+//
+//	gsql.InsertInto(t).Values(&rows).DuplicateUpdate(t.Name).FillIDs().Exec(db)
+//	// rows[i].ID 均已填充
+func (b *insertBuilderWithValues[T]) FillIDs() *insertBuilderWithValues[T] {
+	b.fillIDs = true
+	return b
+}
+
+func (b *insertBuilderWithValues[T]) execFillIDs(db IGormDB) (int64, error) {
+	tx, err := b.newTx(db)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Statement.Parse(tx.Statement.Model); err != nil {
+		return 0, err
+	}
+	idField, err := b.autoIncrementField(tx.Statement.Schema)
+	if err != nil {
+		return 0, err
+	}
+	rows := reflect.ValueOf(b.values).Elem()
+	if rows.Len() == 0 {
+		return 0, nil
+	}
+	ctx := tx.Statement.Context
+
+	// RETURNING 的行顺序没有保证，只有单行时才能与 VALUES 对应，多行时逐行执行
+	// INSERT IGNORE 和 upsert 返回的行数可能少于插入的行数，无法与行对应
+	plain := !b.ignore && !b.onDuplicateUpdate
+	returning := plain && supportsReturning(tx.Dialector)
+	if returning && rows.Len() == 1 {
+		ret, err := b.execDirect(tx, b.values, idField.DBName)
+		if err != nil {
+			return 0, err
+		}
+		if len(ret.returnedIDs) != rows.Len() {
+			return ret.rowsAffected, fmt.Errorf("returned %d ids for %d rows", len(ret.returnedIDs), rows.Len())
+		}
+		for i, id := range ret.returnedIDs {
+			if err := idField.Set(ctx, rows.Index(i), id); err != nil {
+				return ret.rowsAffected, err
+			}
+		}
+		return ret.rowsAffected, nil
+	}
+
+	// LAST_INSERT_ID 为本条语句生成的第一个 ID，后续行按 AutoIncrementIncrement 连续分配
+	// MariaDB 与 MySQL 相同，其他支持 RETURNING 的数据库逐行执行
+	_, isMySQL := tx.Dialector.(*mysql.Dialector)
+	consecutive := plain && (isMySQL || !returning)
+	for i := 0; i < rows.Len() && consecutive; i++ {
+		_, isZero := idField.ValueOf(ctx, rows.Index(i))
+		consecutive = isZero
+	}
+	if consecutive {
+		ret, err := b.execDirect(tx, b.values, "")
+		if err != nil {
+			return 0, err
+		}
+		if ret.lastInsertID <= 0 {
+			return ret.rowsAffected, nil
+		}
+		for i := 0; i < rows.Len(); i++ {
+			if err := idField.Set(ctx, rows.Index(i), ret.lastInsertID+int64(i)*idField.AutoIncrementIncrement); err != nil {
+				return ret.rowsAffected, err
+			}
+		}
+		return ret.rowsAffected, nil
+	}
+
+	// 逐行执行，每条语句的 LAST_INSERT_ID 或 RETURNING 返回的 ID 即为该行的 ID
+	// 在同一个事务中执行，提交后再写回 ID，失败时不会留下部分插入的行
+	var extra []Assignment
+	if b.onDuplicateUpdate {
+		col := fieldOf(b.table, idField.DBName)
+		extra = append(extra, Set(col, clause.Expr{SQL: "LAST_INSERT_ID(?)", Vars: []any{clause.Column{Name: idField.DBName}}}))
+	}
+	var rowsAffected int64
+	ids := make([]int64, rows.Len())
+	err = withContext(db, b.ctx).Session(&Session{}).Transaction(func(txDB *GormDB) error {
+		for i := 0; i < rows.Len(); i++ {
+			tx, err := b.newTx(txDB)
+			if err != nil {
+				return err
+			}
+			row := (*b.values)[i : i+1]
+			ret, err := b.execDirect(tx, &row, lo.Ternary(returning, idField.DBName, ""), extra...)
+			if err != nil {
+				return err
+			}
+			rowsAffected += ret.rowsAffected
+			ids[i] = ret.lastInsertID
+			if len(ret.returnedIDs) > 0 {
+				ids[i] = ret.returnedIDs[0]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, id := range ids {
+		if _, isZero := idField.ValueOf(ctx, rows.Index(i)); !isZero || id <= 0 {
+			continue
+		}
+		if err := idField.Set(ctx, rows.Index(i), id); err != nil {
+			return rowsAffected, err
+		}
+	}
+	return rowsAffected, nil
+}
+
+// autoIncrementField 返回写回 ID 的字段：优先使用带 FlagAutoIncrement 标志的字段，否则使用 gorm 的自增主键
+func (b *insertBuilderWithValues[T]) autoIncrementField(s *schema.Schema) (*schema.Field, error) {
+	if b.table != nil {
+		if f, ok := lo.Find(tableFields(b.table), func(item field.IField) bool {
+			return hasFieldFlag(item, field.FlagAutoIncrement)
+		}); ok {
+			if ret := s.LookUpField(f.Name()); ret != nil {
+				return ret, nil
+			}
+			return nil, fmt.Errorf("%w: column `%s` not found in `%s`", ErrNoAutoIncrementField, f.Name(), s.Table)
+		}
+	}
+	if f := s.PrioritizedPrimaryField; f != nil && f.AutoIncrement {
+		return f, nil
+	}
+	return nil, fmt.Errorf("%w: table `%s`", ErrNoAutoIncrementField, s.Table)
+}
+
+// fieldOf 返回表结构中指定列名的字段，找不到时返回无表名的字段
+func fieldOf(table any, name string) field.IField {
+	if table != nil {
+		if f, ok := lo.Find(tableFields(table), func(item field.IField) bool {
+			return item.Name() == name
+		}); ok {
+			return f
+		}
+	}
+	return Field(name)
+}
+
+// queryIDs 执行带 RETURNING 的语句并读取返回的 ID
+func queryIDs(stmt *Statement) ([]int64, error) {
+	rows, err := stmt.ConnPool.QueryContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// supportsReturning 判断数据库是否支持 INSERT ... RETURNING
+// MariaDB 10.5+ 支持，MySQL 不支持；非 MySQL 方言中 PostgreSQL 和 SQLite 支持
+func supportsReturning(dialector gorm.Dialector) bool {
+	if d, ok := dialector.(*mysql.Dialector); ok {
+		return d.Config != nil && !d.DisableWithReturning &&
+			strings.Contains(d.ServerVersion, "MariaDB") && versionAtLeast(d.ServerVersion, 10, 5, 0)
+	}
+	switch dialector.Name() {
+	case "postgres", "sqlite":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package gsql_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+	"gorm.io/driver/mysql"
+)
+
+func TestFillIDs_Consecutive(t *testing.T) {
+	table := newKeyedProgressTable()
+	db, pool := openCaptureDB(t, "8.0.36")
+	pool.lastInsertID = 100
+
+	rows := []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}, {ConsumerGroup: "c"}}
+	_, err := gsql.InsertInto(table).Values(&rows).FillIDs().ExecWithResult(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if len(pool.sqls) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(pool.sqls))
+	}
+	for i, row := range rows {
+		if row.ID != int64(100+i) {
+			t.Errorf("rows[%d].ID = %d, 期望 %d", i, row.ID, 100+i)
+		}
+	}
+}
+
+func TestFillIDs_UpsertPerRow(t *testing.T) {
+	table := newKeyedProgressTable()
+	db, pool := openTxCaptureDB(t)
+	pool.lastInsertID = 7
+
+	rows := []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}}
+	err := gsql.InsertInto(table).Values(&rows).
+		DuplicateUpdate(table.GenerationID).
+		FillIDs().
+		Exec(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	// upsert 无法推算连续 ID，在事务中逐行执行并通过 LAST_INSERT_ID(`id`) 返回已存在行的 ID
+	if len(pool.sqls) != len(rows)+2 || pool.sqls[0] != "BEGIN" || pool.sqls[len(pool.sqls)-1] != "COMMIT" {
+		t.Fatalf("unexpected statements: %v", pool.sqls)
+	}
+	for _, sql := range pool.sqls[1 : len(pool.sqls)-1] {
+		if !strings.Contains(sql, "VALUES (?,?,?,?,?) AS `new` ON DUPLICATE KEY UPDATE `generation_id`=`new`.`generation_id`,`id`=LAST_INSERT_ID(`id`)") {
+			t.Errorf("unexpected sql: %s", sql)
+		}
+	}
+	for i, row := range rows {
+		if row.ID != 7 {
+			t.Errorf("rows[%d].ID = %d, 期望 7", i, row.ID)
+		}
+	}
+}
+
+func TestFillIDs_ExecHook(t *testing.T) {
+	table := newKeyedProgressTable()
+	db, _ := openTxCaptureDB(t)
+	hook := &recordingHook{}
+	if err := db.Use(hook); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+
+	rows := []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}}
+	if err := gsql.InsertInto(table).Values(&rows).FillIDs().Exec(db); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	rows = []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}}
+	if err := gsql.InsertInto(table).Values(&rows).DuplicateUpdate(table.GenerationID).FillIDs().Exec(db); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	// 连续 ID 执行一条语句，逐行执行时每条语句都调用钩子
+	expect := []gsql.Operation{gsql.OpInsert, gsql.OpUpsert, gsql.OpUpsert}
+	if len(hook.infos) != len(expect) {
+		t.Fatalf("期望 %d 次执行，实际: %+v", len(expect), hook.infos)
+	}
+	for i, info := range hook.infos {
+		if info.Operation != expect[i] || info.Table != "message_consumer_progress" || !strings.HasPrefix(info.SQL, "INSERT INTO") {
+			t.Errorf("#%d 期望 %s，实际: %s %s %s", i, expect[i], info.Operation, info.Table, info.SQL)
+		}
+	}
+	if !strings.Contains(hook.infos[2].SQL, "LAST_INSERT_ID(`id`)") {
+		t.Errorf("逐行执行的 SQL 不正确: %s", hook.infos[2].SQL)
+	}
+}
+
+func TestFillIDs_ExplicitIDs(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, pool := openTxCaptureDB(t)
+	pool.lastInsertID = 9
+
+	// 部分行已设置 ID 时逐行执行，已设置的 ID 保持不变
+	rows := []MessageConsumerProgress{{ID: 5}, {}}
+	if err := gsql.InsertInto(table).Values(&rows).FillIDs().Exec(db); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if len(pool.sqls) != 4 {
+		t.Fatalf("expected BEGIN, 2 inserts and COMMIT, got %v", pool.sqls)
+	}
+	if rows[0].ID != 5 || rows[1].ID != 9 {
+		t.Errorf("unexpected ids: %d, %d", rows[0].ID, rows[1].ID)
+	}
+}
+
+func TestFillIDs_PerRowRollback(t *testing.T) {
+	table := newKeyedProgressTable()
+	db, pool := openTxCaptureDB(t)
+	pool.err = errors.New("duplicate entry")
+	pool.failAt = 2
+
+	rows := []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}, {ConsumerGroup: "c"}}
+	n, err := gsql.InsertIgnore(table).Values(&rows).FillIDs().ExecWithResult(db)
+	if err == nil || n != 0 {
+		t.Fatalf("expected error and 0 rows, got %d %v", n, err)
+	}
+	// 第二行失败时回滚整批，第三行不再执行，已插入的行不写回 ID
+	if len(pool.sqls) != 4 || pool.sqls[0] != "BEGIN" || pool.sqls[3] != "ROLLBACK" {
+		t.Errorf("unexpected statements: %v", pool.sqls)
+	}
+	for i, row := range rows {
+		if row.ID != 0 {
+			t.Errorf("rows[%d].ID = %d, 期望 0", i, row.ID)
+		}
+	}
+}
+
+func TestFillIDs_Returning(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	m := gsqltest.New(t, gsqltest.WithServerVersion("10.11.2-MariaDB"))
+	m.ExpectQueryContains("RETURNING `id`").WillReturnRows(gsqltest.NewRows("id").AddRow(11))
+
+	// 单行时 RETURNING 返回的 ID 一定属于该行
+	rows := []MessageConsumerProgress{{ConsumerGroup: "a"}}
+	n, err := gsql.InsertInto(table).Values(&rows).FillIDs().ExecWithResult(m)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if n != 1 || rows[0].ID != 11 {
+		t.Errorf("unexpected result: n=%d id=%d", n, rows[0].ID)
+	}
+
+	// 多行时 RETURNING 的顺序没有保证，MariaDB 与 MySQL 相同使用 LAST_INSERT_ID 连续分配
+	m.ExpectExecContains("INSERT INTO").WillReturnResult(21, 2)
+	rows = []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}}
+	if _, err := gsql.InsertInto(table).Values(&rows).FillIDs().ExecWithResult(m); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if rows[0].ID != 21 || rows[1].ID != 22 {
+		t.Errorf("unexpected ids: %d,%d", rows[0].ID, rows[1].ID)
+	}
+	if sqls := m.SQLs(); len(sqls) != 2 || strings.Contains(sqls[1], "RETURNING") {
+		t.Errorf("多行不应使用 RETURNING，实际: %v", sqls)
+	}
+}
+
+// sqliteDialector 名字为 sqlite 的 MySQL 方言，用于测试不保证 RETURNING 顺序的数据库
+type sqliteDialector struct {
+	*mysql.Dialector
+}
+
+func (sqliteDialector) Name() string { return "sqlite" }
+
+func TestFillIDs_ReturningPerRow(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	m := gsqltest.New(t)
+	db := m.DB()
+	db.Dialector = sqliteDialector{db.Dialector.(*mysql.Dialector)}
+	m.ExpectQueryContains("RETURNING `id`").WillReturnRows(gsqltest.NewRows("id").AddRow(5))
+	m.ExpectQueryContains("RETURNING `id`").WillReturnRows(gsqltest.NewRows("id").AddRow(3))
+
+	// 多行时在事务中逐行执行，每条语句返回的 ID 属于该行
+	rows := []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}}
+	n, err := gsql.InsertInto(table).Values(&rows).FillIDs().ExecWithResult(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if n != 2 || rows[0].ID != 5 || rows[1].ID != 3 {
+		t.Errorf("unexpected result: n=%d ids=%d,%d", n, rows[0].ID, rows[1].ID)
+	}
+	if len(m.Statements()) != 2 {
+		t.Errorf("期望逐行执行，实际: %v", m.SQLs())
+	}
+}
+
+func TestFillIDs_ReturningIgnore(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	m := gsqltest.New(t, gsqltest.WithServerVersion("10.11.2-MariaDB"))
+	m.ExpectExecContains("INSERT IGNORE INTO").WillReturnResult(21, 1)
+	m.ExpectExecContains("INSERT IGNORE INTO").WillReturnResult(0, 0)
+
+	// 第二行被忽略，RETURNING 只会返回一个 ID，改为逐行执行
+	rows := []MessageConsumerProgress{{ConsumerGroup: "a"}, {ConsumerGroup: "b"}}
+	n, err := gsql.InsertIgnore(table).Values(&rows).FillIDs().ExecWithResult(m)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if n != 1 || rows[0].ID != 21 || rows[1].ID != 0 {
+		t.Errorf("unexpected result: n=%d ids=%d,%d", n, rows[0].ID, rows[1].ID)
+	}
+	for _, sql := range m.SQLs() {
+		if strings.Contains(sql, "RETURNING") {
+			t.Errorf("INSERT IGNORE 不应使用 RETURNING: %s", sql)
+		}
+	}
+}
+
+func TestFillIDs_NoAutoIncrementField(t *testing.T) {
+	type noKey struct {
+		Name string `gorm:"column:name"`
+	}
+	table := noKeyTable[noKey]{Name: gsql.StringFieldOf[string]("no_key", "name")}
+	db, _ := openCaptureDB(t, "8.0.36")
+
+	err := gsql.InsertInto(table).Value(noKey{Name: "a"}).FillIDs().Exec(db)
+	if !errors.Is(err, gsql.ErrNoAutoIncrementField) {
+		t.Fatalf("期望 ErrNoAutoIncrementField，实际: %v", err)
+	}
+}
+
+type noKeyTable[T any] struct {
+	Name gsql.StringField[string]
+}
+
+func (noKeyTable[T]) TableName() string { return "no_key" }
+
+func (noKeyTable[T]) ModelType() T {
+	var def T
+	return def
+}
@@ -1,12 +1,15 @@
 package gsql_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/donutnomad/gsql"
 	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
+	"github.com/donutnomad/gsql/gsqltest"
 	"github.com/samber/lo"
 )
 
@@ -273,3 +276,134 @@ func TestValues_InSelect(t *testing.T) {
 		t.Errorf("期望包含 VALUES()，实际: %s", sql2)
 	}
 }
+
+// 带键标志的消息消费者进度表，用于 DuplicateUpdateNonKeys
+type keyedProgressTable struct {
+	MessageConsumerProgressTable
+}
+
+func (t keyedProgressTable) AllFields() field.BaseFields {
+	return field.BaseFields{t.ID, t.ConsumerGroup, t.LastConsumedMessageID, t.GenerationID, t.CreatedAt, t.UpdatedAt}
+}
+
+func newKeyedProgressTable() keyedProgressTable {
+	tableName := "message_consumer_progress"
+	t := keyedProgressTable{NewMessageConsumerProgressTable()}
+	t.ID = gsql.IntFieldOf[int64](tableName, "id", field.FlagPrimaryKey|field.FlagAutoIncrement)
+	t.ConsumerGroup = gsql.StringFieldOf[string](tableName, "consumer_group", field.FlagUniqueIndex)
+	return t
+}
+
+// TestDuplicateUpdateNonKeys 测试自动更新所有非键列
+func TestDuplicateUpdateNonKeys(t *testing.T) {
+	table := newKeyedProgressTable()
+
+	sql := gsql.InsertInto(table).Value(MessageConsumerProgress{}).DuplicateUpdateNonKeys().ToSQL()
+	expect := "ON DUPLICATE KEY UPDATE `last_consumed_message_id`=VALUES(`message_consumer_progress`.`last_consumed_message_id`),`generation_id`=VALUES(`message_consumer_progress`.`generation_id`),`created_at`=VALUES(`message_consumer_progress`.`created_at`),`updated_at`=VALUES(`message_consumer_progress`.`updated_at`)"
+	if sql != expect {
+		t.Errorf("期望 %s，实际: %s", expect, sql)
+	}
+}
+
+// TestDuplicateUpdateAllExcept 测试更新除指定列以外的所有列
+func TestDuplicateUpdateAllExcept(t *testing.T) {
+	// 未实现 AllFields 时使用结构体中的字段
+	table := NewMessageConsumerProgressTable()
+
+	sql := gsql.InsertInto(table).Value(MessageConsumerProgress{}).
+		DuplicateUpdateAllExcept(table.ID, table.CreatedAt).
+		ToSQL()
+	expect := "ON DUPLICATE KEY UPDATE `consumer_group`=VALUES(`message_consumer_progress`.`consumer_group`),`last_consumed_message_id`=VALUES(`message_consumer_progress`.`last_consumed_message_id`),`generation_id`=VALUES(`message_consumer_progress`.`generation_id`),`updated_at`=VALUES(`message_consumer_progress`.`updated_at`)"
+	if sql != expect {
+		t.Errorf("期望 %s，实际: %s", expect, sql)
+	}
+
+	// 主键始终跳过
+	sql = gsql.InsertInto(newKeyedProgressTable()).Value(MessageConsumerProgress{}).
+		DuplicateUpdateAllExcept(table.CreatedAt, table.UpdatedAt).
+		ToSQL()
+	expect = "ON DUPLICATE KEY UPDATE `consumer_group`=VALUES(`message_consumer_progress`.`consumer_group`),`last_consumed_message_id`=VALUES(`message_consumer_progress`.`last_consumed_message_id`),`generation_id`=VALUES(`message_consumer_progress`.`generation_id`)"
+	if sql != expect {
+		t.Errorf("期望 %s，实际: %s", expect, sql)
+	}
+}
+
+// TestDuplicateUpdate_OnlyIfNewer 测试基于版本号的条件更新
+func TestDuplicateUpdate_OnlyIfNewer(t *testing.T) {
+	table := newKeyedProgressTable()
+
+	sql := gsql.InsertInto(table).Value(MessageConsumerProgress{}).
+		DuplicateUpdateAllExcept(table.CreatedAt).
+		DuplicateUpdateExpr(gsql.Set(table.UpdatedAt, gsql.Expr("NOW()"))).
+		OnlyIfNewer(table.GenerationID).
+		ToSQL()
+	guard := "IF(VALUES(`message_consumer_progress`.`generation_id`) > `message_consumer_progress`.`generation_id`, "
+	expect := "ON DUPLICATE KEY UPDATE " +
+		"`consumer_group`=" + guard + "VALUES(`message_consumer_progress`.`consumer_group`), `message_consumer_progress`.`consumer_group`)," +
+		"`last_consumed_message_id`=" + guard + "VALUES(`message_consumer_progress`.`last_consumed_message_id`), `message_consumer_progress`.`last_consumed_message_id`)," +
+		"`updated_at`=" + guard + "NOW(), `message_consumer_progress`.`updated_at`)," +
+		"`generation_id`=" + guard + "VALUES(`message_consumer_progress`.`generation_id`), `message_consumer_progress`.`generation_id`)"
+	if sql != expect {
+		t.Errorf("期望 %s，实际: %s", expect, sql)
+	}
+}
+
+// TestDuplicateUpdate_SameColumn 同一列多次赋值时只保留最后一次
+func TestDuplicateUpdate_SameColumn(t *testing.T) {
+	table := newKeyedProgressTable()
+
+	sql := gsql.InsertInto(table).Value(MessageConsumerProgress{}).
+		DuplicateUpdateNonKeys().
+		DuplicateUpdateExpr(gsql.Set(table.CreatedAt, table.CreatedAt)).
+		DuplicateUpdate(table.GenerationID).
+		ToSQL()
+	expect := "ON DUPLICATE KEY UPDATE `last_consumed_message_id`=VALUES(`message_consumer_progress`.`last_consumed_message_id`),`generation_id`=VALUES(`message_consumer_progress`.`generation_id`),`created_at`=`message_consumer_progress`.`created_at`,`updated_at`=VALUES(`message_consumer_progress`.`updated_at`)"
+	if sql != expect {
+		t.Errorf("期望 %s，实际: %s", expect, sql)
+	}
+}
+
+// 只有键列的消息消费者进度表，DuplicateUpdateNonKeys 没有可以更新的列
+type keysOnlyProgressTable struct {
+	keyedProgressTable
+}
+
+func (t keysOnlyProgressTable) AllFields() field.BaseFields {
+	return field.BaseFields{t.ID, t.ConsumerGroup}
+}
+
+// TestDuplicateUpdate_Empty 赋值列表为空时返回错误，而不是退回到更新所有列
+func TestDuplicateUpdate_Empty(t *testing.T) {
+	table := newKeyedProgressTable()
+	m := gsqltest.New(t)
+	row := MessageConsumerProgress{ConsumerGroup: "g"}
+
+	err := gsql.InsertInto(keysOnlyProgressTable{table}).Value(row).DuplicateUpdateNonKeys().Exec(m)
+	if !errors.Is(err, gsql.ErrEmptyDuplicateUpdate) {
+		t.Errorf("NonKeys: 期望 ErrEmptyDuplicateUpdate，实际: %v", err)
+	}
+	err = gsql.InsertInto(table).Value(row).
+		DuplicateUpdateAllExcept(table.LastConsumedMessageID, table.GenerationID, table.CreatedAt, table.UpdatedAt, table.ConsumerGroup).
+		Exec(m)
+	if !errors.Is(err, gsql.ErrEmptyDuplicateUpdate) {
+		t.Errorf("AllExcept: 期望 ErrEmptyDuplicateUpdate，实际: %v", err)
+	}
+	rows := []MessageConsumerProgress{row}
+	_, err = gsql.InsertInto(table).Values(&rows).DuplicateUpdate().OnlyIfNewer(table.GenerationID).FillIDs().ExecWithResult(m)
+	if !errors.Is(err, gsql.ErrEmptyDuplicateUpdate) {
+		t.Errorf("OnlyIfNewer: 期望 ErrEmptyDuplicateUpdate，实际: %v", err)
+	}
+	if n := len(m.Statements()); n != 0 {
+		t.Errorf("不应执行任何语句，实际执行了 %d 条", n)
+	}
+
+	// 后续的自定义赋值补上了赋值列表
+	m.ExpectExecContains("ON DUPLICATE KEY UPDATE").WillReturnResult(1, 1)
+	err = gsql.InsertInto(keysOnlyProgressTable{table}).Value(row).
+		DuplicateUpdateNonKeys().
+		DuplicateUpdateExpr(gsql.Set(table.UpdatedAt, gsql.Expr("NOW()"))).
+		Exec(m)
+	if err != nil {
+		t.Errorf("exec: %v", err)
+	}
+}
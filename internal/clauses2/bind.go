@@ -0,0 +1,119 @@
+package clauses2
+
+import (
+	"strings"
+
+	"github.com/donutnomad/gsql/clause"
+	"gorm.io/gorm"
+)
+
+// BoundColumnsKey 语句参数对应列的记录在 Statement.Settings 中的键
+const BoundColumnsKey = "gsql:bound_columns"
+
+// BoundColumns 记录语句中每个参数对应的列，用于日志脱敏
+// 只记录 Stmt 自身构建时添加的参数，子查询等其他语句中的参数视为无法确定列
+type BoundColumns struct {
+	Stmt    *gorm.Statement
+	columns []string // 与 Stmt.Vars 下标一致，无法确定时为空字符串
+	sql     string   // 记录最后一个参数时已构建的 SQL
+}
+
+// Columns 返回 sql 中每个参数对应的列
+// sql 不是记录的语句时（如在模型钩子中使用同一个 context 执行的其他语句）返回 false
+// gorm 执行结束后会清空 Stmt.SQL，因此按记录时的 SQL 前缀判断
+func (b *BoundColumns) Columns(sql string, vars int) ([]string, bool) {
+	if !strings.HasPrefix(sql, b.sql) || len(b.columns) > vars {
+		return nil, false
+	}
+	return b.columns, true
+}
+
+// Reset 清空记录，语句执行并输出日志后调用，同一个 Statement 再次构建时重新记录
+func (b *BoundColumns) Reset() {
+	b.columns, b.sql = b.columns[:0], ""
+}
+
+// record 将 [start, end) 中尚未记录的参数记为 column，内层表达式已记录的列优先
+func (b *BoundColumns) record(start, end int, column string) {
+	for len(b.columns) < end {
+		b.columns = append(b.columns, "")
+	}
+	for i := start; i < end; i++ {
+		if b.columns[i] == "" {
+			b.columns[i] = column
+		}
+	}
+	b.sql = b.Stmt.SQL.String()
+}
+
+// LookupBoundColumns 返回 builder 对应语句的参数列记录，没有开启记录时返回 nil
+func LookupBoundColumns(builder clause.Builder) *BoundColumns {
+	stmt, ok := StatementOf(builder)
+	if !ok {
+		return nil
+	}
+	v, ok := stmt.Settings.Load(BoundColumnsKey)
+	if !ok {
+		return nil
+	}
+	if b, ok := v.(*BoundColumns); ok && b.Stmt == stmt {
+		return b
+	}
+	return nil
+}
+
+// BindVar 与 builder.AddVar 相同地添加参数 value，并将其中的参数记为 column 列
+func BindVar(builder clause.Builder, column string, value any) {
+	bind(builder, column, func() { builder.AddVar(builder, value) })
+}
+
+// bind 调用 add 添加参数，并将新增的参数记为 column 列
+func bind(builder clause.Builder, column string, add func()) {
+	b := LookupBoundColumns(builder)
+	if b == nil || column == "" {
+		add()
+		return
+	}
+	start := len(b.Stmt.Vars)
+	add()
+	b.record(start, len(b.Stmt.Vars), column)
+}
+
+// BoundVar 绑定到列的参数，构建时与直接添加 Value 相同
+type BoundVar struct {
+	Column string
+	Value  any
+}
+
+// Bind 返回绑定到 expr 所在列的参数，expr 不是列时原样返回 value
+func Bind(expr any, value any) any {
+	if q := ColumnOf(expr); q != nil {
+		return BoundVar{Column: q.ColumnName, Value: value}
+	}
+	return value
+}
+
+// Build 与在表达式中直接使用 Value 相同，是否加括号由 Value 决定
+func (v BoundVar) Build(builder clause.Builder) {
+	bind(builder, v.Column, func() { clause.AddVarWithParens(builder, v.Value) })
+}
+
+// NeedsParentheses 括号由 Build 按 Value 添加
+func (v BoundVar) NeedsParentheses() bool {
+	return false
+}
+
+// ColumnOf 沿 Unwrap 返回 v 对应的列，不是列时返回 nil
+func ColumnOf(v any) *ColumnQuote {
+	for range 4 {
+		switch e := v.(type) {
+		case *ColumnQuote:
+			return e
+		case interface{ Unwrap() clause.Expression }:
+			v = e.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
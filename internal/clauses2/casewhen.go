@@ -44,14 +44,25 @@ var _ clause.Expression = (*CaseWhenExpr)(nil)
 
 func (c CaseWhenExpr) Build(builder clause.Builder) {
 	writer := &types.SafeWriter{Builder: builder}
+	// 格式化输出时每个 WHEN、ELSE 各占一行
+	pretty, _ := builder.(clause.PrettyBuilder)
+	newline := func() {
+		if pretty != nil {
+			pretty.Newline()
+		}
+	}
 
 	writer.WriteString("CASE")
+	if pretty != nil {
+		pretty.Indent()
+	}
 
 	if c.Simple != nil {
 		// 简单 CASE: CASE expression WHEN value1 THEN result1 ...
 		writer.WriteString(" ")
 		writer.AddVar(writer, c.Simple.expression)
 		for i, value := range c.Simple.values {
+			newline()
 			writer.WriteString(" WHEN ")
 			writer.AddVar(writer, value)
 			writer.WriteString(" THEN ")
@@ -59,12 +70,14 @@ func (c CaseWhenExpr) Build(builder clause.Builder) {
 		}
 		// ELSE 子句（可选）
 		if !lo.IsNil(c.Simple.elseResult) {
+			newline()
 			writer.WriteString(" ELSE ")
 			writer.AddVar(writer, c.Simple.elseResult)
 		}
 	} else if c.Search != nil {
 		// 搜索式 CASE: CASE WHEN condition1 THEN result1 ...
 		for _, pair := range c.Search.whenPairs {
+			newline()
 			writer.WriteString(" WHEN ")
 			writer.AddVar(writer, pair.condition)
 			writer.WriteString(" THEN ")
@@ -72,10 +85,15 @@ func (c CaseWhenExpr) Build(builder clause.Builder) {
 		}
 		// ELSE 子句（可选）
 		if c.Search.elseValue != nil {
+			newline()
 			writer.WriteString(" ELSE ")
 			writer.AddVar(writer, c.Search.elseValue)
 		}
 	}
 
+	if pretty != nil {
+		pretty.Dedent()
+	}
+	newline()
 	writer.WriteString(" END")
 }
@@ -15,6 +15,9 @@ type ColumnQuote struct {
 	TableName  string // 表名
 	ColumnName string // 字段名
 	Alias      string // As Name
+	// RowAlias 为 true 时 TableName 是 INSERT 的行别名（见 gsql.RowAlias），
+	// 不支持行别名语法或语句没有行别名时输出 VALUES(`col`)
+	RowAlias bool
 }
 
 // NoAS remove AS `xxx`
@@ -36,7 +39,22 @@ func (c *ColumnQuote) Name() string {
 	return lo.Ternary(len(c.Alias) > 0, c.Alias, c.ColumnName)
 }
 
+// RowValue 返回引用插入行中该列的值，行别名列不带表名
+func (c *ColumnQuote) RowValue() RowValue {
+	if c.RowAlias {
+		return RowValue{ColumnName: c.ColumnName}
+	}
+	return RowValue{TableName: c.TableName, ColumnName: c.ColumnName}
+}
+
 func (c *ColumnQuote) Build(builder clause.Builder) {
+	// 行别名列在不支持行别名语法的服务器上回退为 VALUES(`col`)
+	if c.RowAlias && len(c.Alias) == 0 {
+		if alias, ok := LookupRowAlias(builder); !ok || alias.Legacy {
+			c.RowValue().Build(builder)
+			return
+		}
+	}
 	if len(c.TableName) > 0 {
 		builder.WriteQuoted(c.TableName)
 		builder.WriteString(".")
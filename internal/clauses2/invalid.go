@@ -0,0 +1,22 @@
+package clauses2
+
+import (
+	"errors"
+
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidEnumValue 枚举字段的取值不在 Values() 返回的取值中
+var ErrInvalidEnumValue = errors.New("invalid enum value")
+
+// Invalid 构造时已发现错误的表达式，构建时记录 Err，执行时返回 Err 而不会发送到数据库
+// 仍按原样输出 Expression，ToSQL 可以看到出错的语句
+type Invalid struct {
+	clause.Expression
+	Err error
+}
+
+func (e Invalid) Build(builder clause.Builder) {
+	addError(builder, e.Err)
+	e.Expression.Build(builder)
+}
@@ -0,0 +1,165 @@
+package clauses2
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// ErrRegexpMatchType match_type 包含 "cimnu" 以外的字符，或当前方言不支持该标志
+var ErrRegexpMatchType = errors.New("invalid regexp match type")
+
+// RegexpFunc 正则表达式函数类型
+type RegexpFunc int
+
+const (
+	RegexpLike RegexpFunc = iota
+	RegexpNotLike
+	RegexpReplace
+	RegexpSubstr
+	RegexpInstr
+)
+
+// Regexp 正则表达式函数，根据方言输出不同的写法
+//   - MySQL: REGEXP_LIKE / REGEXP_REPLACE / REGEXP_SUBSTR / REGEXP_INSTR
+//   - PostgreSQL: ~ / ~* / regexp_replace(..., 'g') / regexp_substr / regexp_instr
+//
+// Pattern、Replacement 与 MatchType 均作为参数绑定
+// MatchType 只能由 "cimnu" 组成，PostgreSQL 只支持 c、i 两种匹配标志，不合法时记录 ErrRegexpMatchType
+type Regexp struct {
+	Func        RegexpFunc
+	Expr        clause.Expression
+	Pattern     string
+	Replacement string
+	MatchType   string // MySQL match_type，如 "i"、"c"、"mn"
+}
+
+func (r Regexp) Build(builder clause.Builder) {
+	if i := strings.IndexFunc(r.MatchType, func(c rune) bool { return !strings.ContainsRune("cimnu", c) }); i >= 0 {
+		addError(builder, fmt.Errorf("%w: %q, expected characters in \"cimnu\"", ErrRegexpMatchType, r.MatchType))
+	}
+	if dialectName(builder) == "postgres" {
+		r.buildPostgres(builder)
+		return
+	}
+	switch r.Func {
+	case RegexpLike, RegexpNotLike:
+		if r.Func == RegexpNotLike {
+			builder.WriteString("NOT ")
+		}
+		r.writeCall(builder, "REGEXP_LIKE", "")
+	case RegexpReplace:
+		// pos=1, occurrence=0 表示替换全部匹配，与不带可选参数时一致
+		r.writeCall(builder, "REGEXP_REPLACE", ", 1, 0")
+	case RegexpSubstr:
+		r.writeCall(builder, "REGEXP_SUBSTR", ", 1, 1")
+	case RegexpInstr:
+		r.writeCall(builder, "REGEXP_INSTR", ", 1, 1, 0")
+	}
+}
+
+// writeCall 输出 NAME(expr, pattern[, replacement][<optional>, match_type])
+// 未指定 MatchType 时省略可选参数
+func (r Regexp) writeCall(builder clause.Builder, name string, optional string) {
+	builder.WriteString(name)
+	builder.WriteString("(")
+	builder.AddVar(builder, r.Expr)
+	builder.WriteString(", ")
+	builder.AddVar(builder, r.Pattern)
+	if r.Func == RegexpReplace {
+		builder.WriteString(", ")
+		builder.AddVar(builder, r.Replacement)
+	}
+	if r.MatchType != "" {
+		builder.WriteString(optional)
+		builder.WriteString(", ")
+		builder.AddVar(builder, r.MatchType)
+	}
+	builder.WriteString(")")
+}
+
+func (r Regexp) buildPostgres(builder clause.Builder) {
+	insensitive, err := postgresCaseInsensitive(r.MatchType)
+	if err != nil {
+		addError(builder, err)
+	}
+	flags := ""
+	if insensitive {
+		flags = "i"
+	}
+	switch r.Func {
+	case RegexpLike, RegexpNotLike:
+		builder.AddVar(builder, r.Expr)
+		op := " ~ "
+		if r.Func == RegexpNotLike {
+			op = " !~ "
+		}
+		if insensitive {
+			op = op[:len(op)-1] + "* "
+		}
+		builder.WriteString(op)
+		builder.AddVar(builder, r.Pattern)
+	case RegexpReplace:
+		// PostgreSQL 默认只替换第一个匹配，g 标志替换全部
+		builder.WriteString("regexp_replace(")
+		builder.AddVar(builder, r.Expr)
+		builder.WriteString(", ")
+		builder.AddVar(builder, r.Pattern)
+		builder.WriteString(", ")
+		builder.AddVar(builder, r.Replacement)
+		builder.WriteString(", ")
+		builder.AddVar(builder, "g"+flags)
+		builder.WriteString(")")
+	case RegexpSubstr:
+		r.writePostgresCall(builder, "regexp_substr", ", 1, 1", flags)
+	case RegexpInstr:
+		r.writePostgresCall(builder, "regexp_instr", ", 1, 1, 0", flags)
+	}
+}
+
+func (r Regexp) writePostgresCall(builder clause.Builder, name string, optional string, flags string) {
+	builder.WriteString(name)
+	builder.WriteString("(")
+	builder.AddVar(builder, r.Expr)
+	builder.WriteString(", ")
+	builder.AddVar(builder, r.Pattern)
+	if flags != "" {
+		builder.WriteString(optional)
+		builder.WriteString(", ")
+		builder.AddVar(builder, flags)
+	}
+	builder.WriteString(")")
+}
+
+// postgresCaseInsensitive 将 MySQL match_type 转换为 PostgreSQL 的大小写敏感设置，c 与 i 同时出现时以最后一个为准
+func postgresCaseInsensitive(matchType string) (bool, error) {
+	insensitive := false
+	for _, c := range matchType {
+		switch c {
+		case 'i':
+			insensitive = true
+		case 'c':
+			insensitive = false
+		default:
+			return insensitive, fmt.Errorf("%w: %q is not supported on postgres", ErrRegexpMatchType, string(c))
+		}
+	}
+	return insensitive, nil
+}
+
+// addError 将错误记录到 builder 对应的 Statement，无法获取 Statement 时忽略
+func addError(builder clause.Builder, err error) {
+	if stmt, ok := StatementOf(builder); ok && stmt.DB != nil {
+		_ = stmt.AddError(err)
+	}
+}
+
+func dialectName(builder clause.Builder) string {
+	stmt, ok := StatementOf(builder)
+	if !ok || stmt.DB == nil || stmt.Dialector == nil {
+		return ""
+	}
+	return stmt.Dialector.Name()
+}
@@ -0,0 +1,66 @@
+package clauses2
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RowAliasKey 当前 INSERT 语句行别名在 Statement.Settings 中的键
+const RowAliasKey = "gsql:row_alias"
+
+// RowAlias INSERT INTO ... VALUES (...) AS new 中的行别名
+// Legacy 为 true 时表示服务器不支持行别名语法（MySQL < 8.0.19 或 MariaDB），
+// 此时对别名列的引用 `new`.`col` 回退为 VALUES(`col`)
+type RowAlias struct {
+	Name   string
+	Legacy bool
+}
+
+// StatementOf 返回 builder 对应的 *gorm.Statement
+// 包装了 Statement 的 builder（如生成指纹时使用的 builder）通过 GormStatement 方法返回
+func StatementOf(builder clause.Builder) (*gorm.Statement, bool) {
+	switch b := builder.(type) {
+	case *gorm.Statement:
+		return b, true
+	case interface{ GormStatement() *gorm.Statement }:
+		return b.GormStatement(), true
+	}
+	return nil, false
+}
+
+// LookupRowAlias 从 builder 中读取当前语句的行别名
+func LookupRowAlias(builder clause.Builder) (RowAlias, bool) {
+	stmt, ok := StatementOf(builder)
+	if !ok {
+		return RowAlias{}, false
+	}
+	v, ok := stmt.Settings.Load(RowAliasKey)
+	if !ok {
+		return RowAlias{}, false
+	}
+	ret, ok := v.(RowAlias)
+	return ret, ok
+}
+
+// RowValue 引用插入行中某列的值
+// 支持行别名语法时输出 `new`.`col`，否则输出 VALUES(`table`.`col`)，TableName 为空时输出 VALUES(`col`)
+type RowValue struct {
+	TableName  string
+	ColumnName string
+}
+
+func (v RowValue) Build(builder clause.Builder) {
+	if alias, ok := LookupRowAlias(builder); ok && !alias.Legacy {
+		builder.WriteQuoted(alias.Name)
+		builder.WriteString(".")
+		builder.WriteQuoted(v.ColumnName)
+		return
+	}
+	builder.WriteString("VALUES(")
+	if v.TableName != "" {
+		builder.WriteQuoted(v.TableName)
+		builder.WriteString(".")
+	}
+	builder.WriteQuoted(v.ColumnName)
+	builder.WriteString(")")
+}
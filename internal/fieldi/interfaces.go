@@ -29,3 +29,10 @@ type IField interface {
 	As(alias string) IField
 	Alias() string
 }
+
+// ITypedField 能报告自身类型族的字段，如 IntField[int64] 和 IntExpr[int] 均返回 "Int"
+// 类型未知时 TypeFamily 返回空字符串
+type ITypedField interface {
+	IField
+	TypeFamily() string
+}
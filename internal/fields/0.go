@@ -20,6 +20,9 @@ type Expressions[T any] interface {
 
 type FunctionName string
 
+// VALUES 引用插入行中的值，Apply(VALUES) 按行别名设置输出 `new`.`col` 或 VALUES(`col`)
+const VALUES FunctionName = "VALUES"
+
 func NewLitExpr[T any](value T) *LitExpr {
 	return &LitExpr{
 		Expression: clause.Expr{SQL: "?", Vars: []any{value}},
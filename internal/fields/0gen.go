@@ -9,6 +9,7 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -18,11 +19,14 @@ import (
 const report = true
 
 type FieldType struct {
-	Name      string // e.g., "IntField"
-	InnerExpr string // e.g., "IntExpr"
-	InnerName string // e.g., "IntExpr" (field name in struct)
-	Column    string
-	Methods   []ExprMethod // collected methods from InnerExpr
+	Name       string // e.g., "IntField"
+	InnerExpr  string // e.g., "IntExpr"
+	InnerName  string // e.g., "IntExpr" (field name in struct)
+	Column     string
+	Constraint string       // type parameter constraint, e.g., "any"
+	Skip       []string     // methods of InnerExpr that are not re-exported
+	Extra      []Param      // extra struct fields, copied by WithAlias/WithTable
+	Methods    []ExprMethod // collected methods from InnerExpr
 }
 
 // ExprMethod represents a public method of an Expr type
@@ -49,16 +53,46 @@ var fieldTypes = []FieldType{
 	newFieldType("TimeExpr"),
 	newFieldType("ScalarExpr"),
 	newFieldType("JsonExpr"),
+	newFieldType("GeometryExpr"),
+	withColumn(newFieldType("BoolExpr"), "BoolFieldColumn"), // BoolColumn 保留在 column.go 中，返回 ScalarColumnBuilder[bool]
+	// EnumField 只接受实现 Values() []T 的类型，TypeFamily、Set、取值校验的 Eq/In 等、排序等枚举专用方法在 enum.go 中
+	enumFieldType("Enum", "ScalarExpr", "EnumValue[T]", "TypeFamily", "Eq", "EqOpt", "Not", "NotOpt", "In", "NotIn"),
+	// UUIDField 的取值按 UUID_TO_BIN 绑定，TypeFamily、Set、Eq/In 等、Text、WithoutSwap 在 uuid.go 中，文本转换统一使用 Text
+	withExtra(enumFieldType("UUID", "ScalarExpr", "UUIDValue", "TypeFamily", "Eq", "EqOpt", "Not", "NotOpt", "In", "NotIn",
+		"ToString", "ToInt", "ToFloat", "ToDecimal", "ToTime", "ToDate"), Param{Name: "noSwap", Type: "bool"}),
+}
+
+// withColumn 指定 XxxColumn 构造函数和 XxxColumnBuilder 的名字，避免与已有的同名函数冲突
+func withColumn(t FieldType, column string) FieldType {
+	t.Column = column
+	return t
+}
+
+// withExtra 为字段增加额外的结构体成员，WithAlias、WithTable 时一并复制
+func withExtra(t FieldType, extra ...Param) FieldType {
+	t.Extra = extra
+	return t
+}
+
+// enumFieldType 基于已有的 Expr 生成带类型约束的字段，skip 中的方法不转发，由手写代码实现
+func enumFieldType(name, input, constraint string, skip ...string) FieldType {
+	t := newFieldType(input)
+	t.Name = name + "Field"
+	t.Column = name + "Column"
+	t.Constraint = constraint
+	t.Skip = skip
+	return t
 }
 
 func newFieldType(input string) FieldType {
 	a := strings.TrimSuffix(input, "Expr") + "Field"
 	return FieldType{
-		Name:      a,
-		InnerExpr: strings.TrimSuffix(input, "Expr"),
-		InnerName: input,
-		Column:    strings.TrimSuffix(input, "Expr") + "Column",
-		Methods:   nil, // Will be populated by collectExprMethods
+		Name:       a,
+		InnerExpr:  strings.TrimSuffix(input, "Expr"),
+		InnerName:  input,
+		Column:     strings.TrimSuffix(input, "Expr") + "Column",
+		Constraint: "any",
+		Methods:    nil, // Will be populated by collectExprMethods
 	}
 }
 
@@ -77,13 +111,14 @@ import (
 {{range .}}
 // ==================== {{.Name}} ====================
 
-type {{.Name}}[T any] struct {
+type {{.Name}}[T {{.Constraint}}] struct {
 	expr   {{.InnerName}}[T]
 	flags  types.FieldFlag
-	column *clauses2.ColumnQuote
+	column *clauses2.ColumnQuote{{range .Extra}}
+	{{.Name}} {{.Type}}{{end}}
 }
 
-func {{.Name}}Of[T any](tableName, name string, flags ...types.FieldFlag) {{.Name}}[T] {
+func {{.Name}}Of[T {{.Constraint}}](tableName, name string, flags ...types.FieldFlag) {{.Name}}[T] {
 	q := &clauses2.ColumnQuote{
 		TableName:  tableName,
 		ColumnName: name,
@@ -100,11 +135,11 @@ func {{.Name}}Of[T any](tableName, name string, flags ...types.FieldFlag) {{.Nam
 	return ret
 }
 
-func {{.Column}}[T any](name string) {{.Column}}Builder[T] {
+func {{.Column}}[T {{.Constraint}}](name string) {{.Column}}Builder[T] {
 	return {{.Column}}Builder[T]{name: name}
 }
 
-type {{.Column}}Builder[T any] struct {
+type {{.Column}}Builder[T {{.Constraint}}] struct {
 	name string
 }
 
@@ -133,6 +168,9 @@ func (f {{.Name}}[T]) Expr() {{.InnerName}}[T] {
 func (f {{.Name}}[T]) Apply(functionName FunctionName) {{.InnerName}}[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return {{.InnerExpr}}Of[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -174,7 +212,8 @@ func (f {{.Name}}[T]) As(alias string) fieldi.IField {
 }
 
 func (f {{.Name}}[T]) WithAlias(alias string) {{.Name}}[T] {
-	ret := {{.Name}}Of[T](f.TableName(), f.ColumnName(), f.flags)
+	ret := {{.Name}}Of[T](f.TableName(), f.ColumnName(), f.flags){{range .Extra}}
+	ret.{{.Name}} = f.{{.Name}}{{end}}
 	ret.column.Alias = alias
 	return ret
 }
@@ -184,7 +223,9 @@ func (f {{.Name}}[T]) WithTable(tableName interface{ TableName() string }, field
 	if len(fieldNames) > 0 {
 		name = fieldNames[0]
 	}
-	return {{.Name}}Of[T](tableName.TableName(), name, f.flags)
+	{{if .Extra}}ret := {{.Name}}Of[T](tableName.TableName(), name, f.flags){{range .Extra}}
+	ret.{{.Name}} = f.{{.Name}}{{end}}
+	return ret{{else}}return {{.Name}}Of[T](tableName.TableName(), name, f.flags){{end}}
 }
 
 /////////////// flags ///////////////
@@ -236,7 +277,11 @@ func main() {
 	// Collect methods for each FieldType only when report is true
 	if report {
 		for i := range fieldTypes {
-			fieldTypes[i].Methods = collectExprMethods(fieldTypes[i].InnerName)
+			for _, m := range collectExprMethods(fieldTypes[i].InnerName) {
+				if !slices.Contains(fieldTypes[i].Skip, m.Name) {
+					fieldTypes[i].Methods = append(fieldTypes[i].Methods, m)
+				}
+			}
 		}
 	}
 
@@ -0,0 +1,13 @@
+package fields
+
+import (
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/fieldi"
+)
+
+// Assignment 表示赋值表达式 column = value，用于 ON DUPLICATE KEY UPDATE 和 UPDATE
+// 用于支持自定义更新逻辑，如 column = IF(condition, newValue, oldValue)
+type Assignment struct {
+	Column fieldi.IField
+	Value  clause.Expression
+}
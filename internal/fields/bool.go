@@ -0,0 +1,119 @@
+package fields
+
+import (
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/types"
+	"github.com/samber/lo"
+)
+
+var _ clause.Expression = (*BoolExpr[bool])(nil)
+
+// ==================== BoolExpr 定义 ====================
+
+// BoolExpr 布尔类型表达式，用于 BOOL/TINYINT(1) 字段和返回布尔值的表达式
+// @gentype default=[bool]
+// 本身可以作为条件直接传给 Where/And/Or，IF/COUNT_IF 使用 IsTrue()/IsFalse()，不提供算术和大小比较
+// 示例:
+//
+//	gsql.Select(e.ID).From(e).Where(e.IsActive)         // WHERE `employees`.`is_active`
+//	gsql.Select(e.ID).From(e).Where(e.IsActive.Not())   // WHERE NOT `employees`.`is_active`
+//	e.IsActive.CountIf().As("active_count")             // COUNT(CASE WHEN `is_active` THEN 1 END)
+type BoolExpr[T any] struct {
+	baseComparableImpl[T] // Eq, In, NotIn；Not 被 BoolExpr.Not() 覆盖为取反
+	pointerExprImpl       // IsNull, IsNotNull
+	nullCondFuncSql       // IfNull, Coalesce, NullIf
+	baseExprSql           // Build, ToExpr, As
+}
+
+// Bool creates a BoolExpr[bool] from a clause expression.
+func Bool(expr clause.Expression) BoolExpr[bool] {
+	return BoolOf[bool](expr)
+}
+
+// BoolOf creates a generic BoolExpr[T] from a clause expression.
+func BoolOf[T any](expr clause.Expression) BoolExpr[T] {
+	return BoolExpr[T]{
+		baseComparableImpl: baseComparableImpl[T]{Expression: expr},
+		pointerExprImpl:    pointerExprImpl{Expression: expr},
+		nullCondFuncSql:    nullCondFuncSql{Expression: expr},
+		baseExprSql:        baseExprSql{Expr: expr},
+	}
+}
+
+// BoolVal creates a BoolExpr from a bool literal value.
+func BoolVal[T ~bool | any](val T) BoolExpr[T] {
+	return BoolOf[T](anyToExpr(val))
+}
+
+func BoolFrom[T any](field interface{ FieldType() T }) BoolExpr[T] {
+	return BoolOf[T](anyToExpr(field))
+}
+
+// ==================== 条件 ====================
+
+// IsTrue 值为真 (IS TRUE)，NULL 不满足条件
+// SELECT * FROM employees WHERE is_active IS TRUE;
+func (e BoolExpr[T]) IsTrue() Condition {
+	return Condition{clause.Expr{SQL: "? IS TRUE", Vars: []any{e.Unwrap()}}}
+}
+
+// IsFalse 值为假 (IS FALSE)，NULL 不满足条件
+// SELECT * FROM employees WHERE is_active IS FALSE;
+func (e BoolExpr[T]) IsFalse() Condition {
+	return Condition{clause.Expr{SQL: "? IS FALSE", Vars: []any{e.Unwrap()}}}
+}
+
+// Not 取反 (NOT)
+// SELECT * FROM employees WHERE NOT is_active;
+func (e BoolExpr[T]) Not() BoolExpr[T] {
+	return BoolOf[T](clause.Expr{SQL: "NOT ?", Vars: []any{e.Unwrap()}})
+}
+
+// And 与其它条件组合 (AND)，空条件会被忽略
+// SELECT * FROM employees WHERE is_active AND salary > 5000;
+func (e BoolExpr[T]) And(others ...clause.Expression) Condition {
+	return Condition{clause.And(e.withConditions(others)...)}
+}
+
+// Or 与其它条件组合 (OR)，空条件会被忽略
+// SELECT * FROM employees WHERE is_active OR department = 'HR';
+func (e BoolExpr[T]) Or(others ...clause.Expression) Condition {
+	conds := e.withConditions(others)
+	if len(conds) == 1 {
+		return Condition{conds[0]}
+	}
+	return Condition{clause.Or(conds...)}
+}
+
+func (e BoolExpr[T]) withConditions(others []clause.Expression) []clause.Expression {
+	return append([]clause.Expression{e.Unwrap()}, lo.Filter(others, func(item clause.Expression, _ int) bool {
+		if lo.IsNil(item) {
+			return false
+		}
+		v, ok := item.(types.SQLChecker)
+		return !ok || !v.IsEmptySQL()
+	})...)
+}
+
+// ==================== 聚合 ====================
+
+// CountIf 统计值为真的行数 (COUNT(CASE WHEN ... THEN 1 END))
+// SELECT department, COUNT(CASE WHEN is_active THEN 1 END) FROM employees GROUP BY department;
+func (e BoolExpr[T]) CountIf() IntExpr[int64] {
+	return IntOf[int64](clause.Expr{SQL: "COUNT(CASE WHEN ? THEN 1 END)", Vars: []any{e.Unwrap()}})
+}
+
+// Sum 值为真的行计 1，否则计 0 后求和 (SUM(CASE WHEN ... THEN 1 ELSE 0 END))，兼容不支持对布尔值求和的数据库
+// SELECT SUM(CASE WHEN is_active THEN 1 ELSE 0 END) FROM employees;
+func (e BoolExpr[T]) Sum() IntExpr[int64] {
+	return IntOf[int64](clause.Expr{SQL: "SUM(CASE WHEN ? THEN 1 ELSE 0 END)", Vars: []any{e.Unwrap()}})
+}
+
+func (e BoolExpr[T]) Unwrap() clause.Expression {
+	return e.baseComparableImpl.Expression
+}
+
+// TypeFamily 返回类型族 "Bool"，用于 INSERT 目标列与插入数据的类型校验
+func (e BoolExpr[T]) TypeFamily() string {
+	return "Bool"
+}
@@ -1,5 +1,6 @@
 package fields
 
+// BoolColumn 返回 ScalarField[bool] 的列构造器，BoolField 使用 BoolFieldColumn
 func BoolColumn(name string) ScalarColumnBuilder[bool] {
 	return ScalarColumnBuilder[bool]{name: name}
 }
@@ -68,6 +68,18 @@ func TestBoolColumn_From(t *testing.T) {
 	_ = active.Eq(true)
 }
 
+func TestBoolFieldColumn_From(t *testing.T) {
+	sub := mockTable{tableName: "flags"}
+	active := BoolFieldColumn[bool]("active").From(sub)
+
+	if active.TableName() != "flags" || active.ColumnName() != "active" {
+		t.Errorf("Expected flags.active, got '%s.%s'", active.TableName(), active.ColumnName())
+	}
+
+	// Verify it can be used as a condition
+	_ = active.Not()
+}
+
 func TestTimeColumn_From(t *testing.T) {
 	sub := mockTable{tableName: "events"}
 	createdAt := TimeColumn[any]("created_at").From(sub)
@@ -88,3 +88,8 @@ func (e DateExpr[T]) CastChar(length ...int) StringExpr[string] {
 func (e DateExpr[T]) Unwrap() clause.Expression {
 	return e.numericComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "Date"，用于 INSERT 目标列与插入数据的类型校验
+func (e DateExpr[T]) TypeFamily() string {
+	return "Date"
+}
@@ -101,3 +101,8 @@ func (e DateTimeExpr[T]) CastChar(length ...int) StringExpr[string] {
 func (e DateTimeExpr[T]) Unwrap() clause.Expression {
 	return e.numericComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "DateTime"，用于 INSERT 目标列与插入数据的类型校验
+func (e DateTimeExpr[T]) TypeFamily() string {
+	return "DateTime"
+}
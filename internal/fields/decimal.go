@@ -107,3 +107,8 @@ func (e DecimalExpr[T]) Format(decimals int) StringExpr[string] {
 func (e DecimalExpr[T]) Unwrap() clause.Expression {
 	return e.numericComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "Decimal"，用于 INSERT 目标列与插入数据的类型校验
+func (e DecimalExpr[T]) TypeFamily() string {
+	return "Decimal"
+}
@@ -0,0 +1,179 @@
+package fields
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/clauses2"
+	"github.com/donutnomad/gsql/internal/types"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// EnumValue 枚举类型需要实现的接口，Values 按声明顺序返回所有取值
+// 示例:
+//
+//	type OrderStatus string
+//
+//	const (
+//		OrderPending OrderStatus = "pending"
+//		OrderPaid    OrderStatus = "paid"
+//		OrderClosed  OrderStatus = "closed"
+//	)
+//
+//	func (OrderStatus) Values() []OrderStatus {
+//		return []OrderStatus{OrderPending, OrderPaid, OrderClosed}
+//	}
+type EnumValue[T any] interface {
+	comparable
+	Values() []T
+}
+
+// ==================== EnumField ====================
+
+// EnumField 枚举字段，由 0gen.go 生成到 field_generate.go，这里是枚举专用的方法
+// Eq/In/Set 等方法只接受 T 类型的值，但无类型的字符串常量可以隐式转换为 T，
+// 因此构造条件时会检查取值是否在 Values() 中，不在时执行返回 ErrInvalidEnumValue，语句不会发送到数据库
+// 示例:
+//
+//	Status: gsql.EnumFieldOf[OrderStatus]("orders", "status")
+//	gsql.Select(o.ID).From(o).Where(o.Status.In(OrderPending, OrderPaid)).OrderBy(o.Status.AscDecl())
+
+// TypeFamily 返回类型族 "Enum"，用于 INSERT 目标列与插入数据的类型校验
+func (f EnumField[T]) TypeFamily() string {
+	return "Enum"
+}
+
+// AscDecl 按枚举声明顺序升序排列 (ORDER BY FIELD(col, v1, v2, ...))
+func (f EnumField[T]) AscDecl() types.OrderItem {
+	return types.NewOrder(f.Ordinal(), true)
+}
+
+// DescDecl 按枚举声明顺序降序排列 (ORDER BY FIELD(col, v1, v2, ...) DESC)
+func (f EnumField[T]) DescDecl() types.OrderItem {
+	return types.NewOrder(f.Ordinal(), false)
+}
+
+/////////////// enum ///////////////
+
+// Eq 等于 (=)，value 不在 Values() 中时执行返回 ErrInvalidEnumValue
+func (f EnumField[T]) Eq(value T) Condition {
+	return f.checked(f.expr.Eq(value), value)
+}
+
+// EqOpt 等于 (=)，value 为空时不生成条件
+func (f EnumField[T]) EqOpt(value mo.Option[T]) Condition {
+	if value.IsAbsent() {
+		return emptyCondition
+	}
+	return f.Eq(value.MustGet())
+}
+
+// Not 不等于 (!=)，value 不在 Values() 中时执行返回 ErrInvalidEnumValue
+func (f EnumField[T]) Not(value T) Condition {
+	return f.checked(f.expr.Not(value), value)
+}
+
+// NotOpt 不等于 (!=)，value 为空时不生成条件
+func (f EnumField[T]) NotOpt(value mo.Option[T]) Condition {
+	if value.IsAbsent() {
+		return emptyCondition
+	}
+	return f.Not(value.MustGet())
+}
+
+// In 属于取值列表 (IN)，有取值不在 Values() 中时执行返回 ErrInvalidEnumValue
+func (f EnumField[T]) In(values ...T) Condition {
+	return f.checked(f.expr.In(values...), values...)
+}
+
+// NotIn 不属于取值列表 (NOT IN)，有取值不在 Values() 中时执行返回 ErrInvalidEnumValue
+func (f EnumField[T]) NotIn(values ...T) Condition {
+	return f.checked(f.expr.NotIn(values...), values...)
+}
+
+// Set 赋值 column = value，用于 UPDATE 和 ON DUPLICATE KEY UPDATE
+// value 不在 Values() 中时执行返回 ErrInvalidEnumValue
+// 示例: gsql.Select().From(o).Where(o.ID.Eq(1)).Update(db, o.Status.Set(OrderPaid))
+func (f EnumField[T]) Set(value T) Assignment {
+	var expr clause.Expression = clause.Expr{SQL: "?", Vars: []any{value}}
+	if err := f.check(value); err != nil {
+		expr = clauses2.Invalid{Expression: expr, Err: err}
+	}
+	return Assignment{Column: f, Value: expr}
+}
+
+// check 检查取值是否都在 Values() 中
+func (f EnumField[T]) check(values ...T) error {
+	valid := f.FieldType().Values()
+	for _, v := range values {
+		if !slices.Contains(valid, v) {
+			return fmt.Errorf("%w: %v for column `%s`", clauses2.ErrInvalidEnumValue, v, f.column.ColumnName)
+		}
+	}
+	return nil
+}
+
+// checked 取值不合法时返回执行时报错的条件
+func (f EnumField[T]) checked(cond Condition, values ...T) Condition {
+	if err := f.check(values...); err != nil {
+		return Condition{clauses2.Invalid{Expression: cond.Expression, Err: err}}
+	}
+	return cond
+}
+
+// Ordinal 返回取值在枚举声明顺序中的位置（从1开始），不在枚举中的值返回0 (FIELD)
+// SELECT FIELD(status, 'pending', 'paid', 'closed') FROM orders;
+// 数据库支持: MySQL
+func (f EnumField[T]) Ordinal() IntExpr[int64] {
+	values := f.FieldType().Values()
+	vars := make([]any, 0, len(values)+1)
+	sql := "FIELD(?"
+	vars = append(vars, f.unaliased())
+	for _, v := range values {
+		sql += ", ?"
+		vars = append(vars, v)
+	}
+	return IntOf[int64](clause.Expr{SQL: sql + ")", Vars: vars})
+}
+
+// Label 将枚举值映射为文本，生成 CASE col WHEN ... THEN ... END
+// 按枚举声明顺序输出 WHEN 分支，m 中没有的取值返回 elseValue（省略时为 NULL）
+// 示例: o.Status.Label(map[OrderStatus]string{OrderPending: "待支付", OrderPaid: "已支付"}).As("status_label")
+func (f EnumField[T]) Label(m map[T]string, elseValue ...string) StringExpr[string] {
+	return StringOf[string](enumCase(f, m, elseValue))
+}
+
+// unaliased 返回不带 AS 别名的列，用于函数参数
+func (f EnumField[T]) unaliased() *clauses2.ColumnQuote {
+	return &clauses2.ColumnQuote{TableName: f.column.TableName, ColumnName: f.column.ColumnName, RowAlias: f.column.RowAlias}
+}
+
+// EnumCase 将枚举值映射为任意类型的值，生成 CASE col WHEN ... THEN ... END
+// 按枚举声明顺序输出 WHEN 分支，m 中没有的取值返回 elseValue（省略时为 NULL）
+// 示例:
+//
+//	gsql.EnumCase(o.Status, map[OrderStatus]int{OrderPending: 1, OrderPaid: 2}, 0)
+//	// CASE `orders`.`status` WHEN 'pending' THEN 1 WHEN 'paid' THEN 2 ELSE 0 END
+func EnumCase[T EnumValue[T], R any](f EnumField[T], m map[T]R, elseValue ...R) ScalarExpr[R] {
+	return ScalarOf[R](enumCase(f, m, elseValue))
+}
+
+func enumCase[T EnumValue[T], R any](f EnumField[T], m map[T]R, elseValue []R) clause.Expression {
+	var values, results []clause.Expression
+	for _, v := range f.FieldType().Values() {
+		if r, ok := m[v]; ok {
+			values = append(values, clause.Expr{SQL: "?", Vars: []any{v}})
+			results = append(results, clause.Expr{SQL: "?", Vars: []any{r}})
+		}
+	}
+	var elseResult clause.Expression
+	if len(elseValue) > 0 {
+		elseResult = clause.Expr{SQL: "?", Vars: []any{elseValue[0]}}
+	}
+	if len(values) == 0 {
+		return lo.Ternary[clause.Expression](elseResult != nil, elseResult, clause.Expr{SQL: "NULL"})
+	}
+	return clauses2.CaseWhenExpr{Simple: clauses2.NewSimpleCaseData(f.unaliased(), values, results, elseResult)}
+}
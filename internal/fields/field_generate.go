@@ -68,6 +68,9 @@ func (f IntField[T]) Expr() IntExpr[T] {
 func (f IntField[T]) Apply(functionName FunctionName) IntExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return IntOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -237,6 +240,11 @@ func (f IntField[T]) ToDateTime() DateTimeExpr[string] {
 	return f.expr.ToDateTime()
 }
 
+// TypeFamily 返回类型族 "Int"，用于 INSERT 目标列与插入数据的类型校验
+func (f IntField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -726,6 +734,9 @@ func (f FloatField[T]) Expr() FloatExpr[T] {
 func (f FloatField[T]) Apply(functionName FunctionName) FloatExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return FloatOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -845,6 +856,11 @@ func (f FloatField[T]) Format(decimals int) StringExpr[string] {
 	return f.expr.Format(decimals)
 }
 
+// TypeFamily 返回类型族 "Float"，用于 INSERT 目标列与插入数据的类型校验
+func (f FloatField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -1358,6 +1374,9 @@ func (f DecimalField[T]) Expr() DecimalExpr[T] {
 func (f DecimalField[T]) Apply(functionName FunctionName) DecimalExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return DecimalOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -1482,6 +1501,11 @@ func (f DecimalField[T]) Format(decimals int) StringExpr[string] {
 	return f.expr.Format(decimals)
 }
 
+// TypeFamily 返回类型族 "Decimal"，用于 INSERT 目标列与插入数据的类型校验
+func (f DecimalField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -1931,6 +1955,9 @@ func (f StringField[T]) Expr() StringExpr[T] {
 func (f StringField[T]) Apply(functionName FunctionName) StringExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return StringOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -2203,6 +2230,44 @@ func (f StringField[T]) RPad(length int, padStr string) StringExpr[T] {
 	return f.expr.RPad(length, padStr)
 }
 
+// RegexpLike 正则匹配 (REGEXP_LIKE)，pattern 作为参数绑定
+// matchType 为可选的匹配标志：c 区分大小写，i 不区分大小写，m 多行模式，n 点号匹配换行，u 仅以 \n 作为换行符
+// matchType 包含其它字符时执行返回 ErrRegexpMatchType
+// 数据库支持: MySQL 8.0+, PostgreSQL（输出为 ~ / ~*，仅支持 c、i 标志）
+// SELECT * FROM users WHERE REGEXP_LIKE(email, '^[a-z]+@example\\.com$');
+// SELECT * FROM users WHERE REGEXP_LIKE(name, '^abc', 'i');
+func (f StringField[T]) RegexpLike(pattern string, matchType ...string) clause.Expression {
+	return f.expr.RegexpLike(pattern, matchType...)
+}
+
+// NotRegexpLike 正则不匹配 (NOT REGEXP_LIKE)，参数同 RegexpLike
+// 数据库支持: MySQL 8.0+, PostgreSQL（输出为 !~ / !~*）
+// SELECT * FROM users WHERE NOT REGEXP_LIKE(phone, '^[0-9]+$');
+func (f StringField[T]) NotRegexpLike(pattern string, matchType ...string) clause.Expression {
+	return f.expr.NotRegexpLike(pattern, matchType...)
+}
+
+// RegexpReplace 替换所有匹配正则的子串 (REGEXP_REPLACE)，pattern 和 replacement 作为参数绑定
+// 数据库支持: MySQL 8.0+, PostgreSQL（输出为 regexp_replace(..., 'g')）
+// SELECT REGEXP_REPLACE(content, '[0-9]{11}', '***') FROM users;
+func (f StringField[T]) RegexpReplace(pattern string, replacement string, matchType ...string) StringExpr[T] {
+	return f.expr.RegexpReplace(pattern, replacement, matchType...)
+}
+
+// RegexpSubstr 返回第一个匹配正则的子串，无匹配时返回 NULL (REGEXP_SUBSTR)
+// 数据库支持: MySQL 8.0+, PostgreSQL 15+
+// SELECT REGEXP_SUBSTR(email, '@.*$') FROM users;
+func (f StringField[T]) RegexpSubstr(pattern string, matchType ...string) StringExpr[T] {
+	return f.expr.RegexpSubstr(pattern, matchType...)
+}
+
+// RegexpInstr 返回第一个匹配正则的子串的起始位置（从1开始），未找到返回0 (REGEXP_INSTR)
+// 数据库支持: MySQL 8.0+, PostgreSQL 15+
+// SELECT REGEXP_INSTR(email, '[0-9]') FROM users;
+func (f StringField[T]) RegexpInstr(pattern string, matchType ...string) IntExpr[int64] {
+	return f.expr.RegexpInstr(pattern, matchType...)
+}
+
 // ToDate 将字符串按照指定格式转换为日期/时间 (STR_TO_DATE)
 // SELECT STR_TO_DATE('2023-10-26', '%Y-%m-%d');
 // SELECT STR_TO_DATE('2023年10月26日', '%Y年%m月%d日');
@@ -2219,6 +2284,11 @@ func (f StringField[T]) InetAton() IntExpr[uint32] {
 	return f.expr.InetAton()
 }
 
+// TypeFamily 返回类型族 "String"，用于 INSERT 目标列与插入数据的类型校验
+func (f StringField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -2405,6 +2475,9 @@ func (f DateTimeField[T]) Expr() DateTimeExpr[T] {
 func (f DateTimeField[T]) Apply(functionName FunctionName) DateTimeExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return DateTimeOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -2514,6 +2587,11 @@ func (f DateTimeField[T]) CastChar(length ...int) StringExpr[string] {
 	return f.expr.CastChar(length...)
 }
 
+// TypeFamily 返回类型族 "DateTime"，用于 INSERT 目标列与插入数据的类型校验
+func (f DateTimeField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -2992,6 +3070,9 @@ func (f DateField[T]) Expr() DateExpr[T] {
 func (f DateField[T]) Apply(functionName FunctionName) DateExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return DateOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -3096,6 +3177,11 @@ func (f DateField[T]) CastChar(length ...int) StringExpr[string] {
 	return f.expr.CastChar(length...)
 }
 
+// TypeFamily 返回类型族 "Date"，用于 INSERT 目标列与插入数据的类型校验
+func (f DateField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -3510,6 +3596,9 @@ func (f TimeField[T]) Expr() TimeExpr[T] {
 func (f TimeField[T]) Apply(functionName FunctionName) TimeExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return TimeOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -3615,6 +3704,11 @@ func (f TimeField[T]) CastChar(length ...int) StringExpr[string] {
 	return f.expr.CastChar(length...)
 }
 
+// TypeFamily 返回类型族 "Time"，用于 INSERT 目标列与插入数据的类型校验
+func (f TimeField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -3965,6 +4059,9 @@ func (f ScalarField[T]) Expr() ScalarExpr[T] {
 func (f ScalarField[T]) Apply(functionName FunctionName) ScalarExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return ScalarOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -4078,6 +4175,11 @@ func (f ScalarField[T]) ToDate() DateExpr[T] {
 	return f.expr.ToDate()
 }
 
+// TypeFamily 标量表达式的类型未知，返回空字符串，INSERT 类型校验时视为兼容
+func (f ScalarField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -4224,6 +4326,9 @@ func (f JsonField[T]) Expr() JsonExpr[T] {
 func (f JsonField[T]) Apply(functionName FunctionName) JsonExpr[T] {
 	var expr = f.expr.Unwrap()
 	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return JsonOf[T](v.RowValue())
+		}
 		v.NoAS()
 	}
 	e := clause.Expr{
@@ -4518,6 +4623,11 @@ func (f JsonField[T]) ToScalar() ScalarExpr[string] {
 	return f.expr.ToScalar()
 }
 
+// TypeFamily 返回类型族 "Json"，用于 INSERT 目标列与插入数据的类型校验
+func (f JsonField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
 // Count 计算非NULL值的数量 (COUNT)
 // 数据库支持: MySQL, PostgreSQL, SQLite
 // SELECT COUNT(id) FROM users;
@@ -4605,3 +4715,1022 @@ func (f JsonField[T]) IsNull() Condition {
 func (f JsonField[T]) IsNotNull() Condition {
 	return f.expr.IsNotNull()
 }
+
+// ==================== GeometryField ====================
+
+type GeometryField[T any] struct {
+	expr   GeometryExpr[T]
+	flags  types.FieldFlag
+	column *clauses2.ColumnQuote
+}
+
+func GeometryFieldOf[T any](tableName, name string, flags ...types.FieldFlag) GeometryField[T] {
+	q := &clauses2.ColumnQuote{
+		TableName:  tableName,
+		ColumnName: name,
+		Alias:      "",
+	}
+	ret := GeometryField[T]{
+		expr:   GeometryOf[T](q),
+		column: q,
+		flags:  0,
+	}
+	if len(flags) > 0 {
+		ret.flags = flags[0]
+	}
+	return ret
+}
+
+func GeometryColumn[T any](name string) GeometryColumnBuilder[T] {
+	return GeometryColumnBuilder[T]{name: name}
+}
+
+type GeometryColumnBuilder[T any] struct {
+	name string
+}
+
+func (b GeometryColumnBuilder[T]) From(source interface{ TableName() string }) GeometryField[T] {
+	return GeometryFieldOf[T](source.TableName(), b.name)
+}
+
+/////////////// base ///////////////
+
+func (f GeometryField[T]) Build(builder clause.Builder) {
+	f.expr.Build(builder)
+}
+
+func (f GeometryField[T]) ToExpr() clause.Expression {
+	return f.expr
+}
+
+func (f GeometryField[T]) Unwrap() clause.Expression {
+	return f.expr
+}
+
+func (f GeometryField[T]) Expr() GeometryExpr[T] {
+	return f.expr
+}
+
+func (f GeometryField[T]) Apply(functionName FunctionName) GeometryExpr[T] {
+	var expr = f.expr.Unwrap()
+	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return GeometryOf[T](v.RowValue())
+		}
+		v.NoAS()
+	}
+	e := clause.Expr{
+		SQL:  string(functionName) + "(?)",
+		Vars: []any{expr},
+	}
+	return GeometryOf[T](e)
+}
+
+/////////////// column-name ///////////////
+
+// TableName 返回表名
+func (f GeometryField[T]) TableName() string {
+	return f.column.TableName
+}
+
+// ColumnName 返回列名
+func (f GeometryField[T]) ColumnName() string {
+	return f.column.ColumnName
+}
+
+// Name 返回字段名称
+// 对于expr，返回别名
+// 对于普通字段，有别名的返回别名，否则返回真实名字
+func (f GeometryField[T]) Name() string {
+	return f.column.Name()
+}
+
+func (f GeometryField[T]) Alias() string {
+	return f.column.Alias
+}
+
+func (f GeometryField[T]) FullName() string {
+	return f.column.FullName()
+}
+
+func (f GeometryField[T]) As(alias string) fieldi.IField {
+	return f.WithAlias(alias)
+}
+
+func (f GeometryField[T]) WithAlias(alias string) GeometryField[T] {
+	ret := GeometryFieldOf[T](f.TableName(), f.ColumnName(), f.flags)
+	ret.column.Alias = alias
+	return ret
+}
+
+func (f GeometryField[T]) WithTable(tableName interface{ TableName() string }, fieldNames ...string) GeometryField[T] {
+	name := f.ColumnName()
+	if len(fieldNames) > 0 {
+		name = fieldNames[0]
+	}
+	return GeometryFieldOf[T](tableName.TableName(), name, f.flags)
+}
+
+/////////////// flags ///////////////
+
+func (f GeometryField[T]) FieldType() T {
+	var def T
+	return def
+}
+
+func (f GeometryField[T]) Flags() types.FieldFlag {
+	return f.flags
+}
+
+func (f GeometryField[T]) HasFlag(flag types.FieldFlag) bool {
+	return f.flags&flag != 0
+}
+
+func (f GeometryField[T]) IsPrimaryKey() bool {
+	return f.HasFlag(types.FlagPrimaryKey)
+}
+
+func (f GeometryField[T]) IsUniqueIndex() bool {
+	return f.HasFlag(types.FlagUniqueIndex)
+}
+
+/////////////// asc/desc ///////////////
+
+func (f GeometryField[T]) Asc() types.OrderItem {
+	return types.NewOrder(f, true)
+}
+
+func (f GeometryField[T]) Desc() types.OrderItem {
+	return types.NewOrder(f, false)
+}
+
+/////////////// re-exported methods from GeometryExpr ///////////////
+
+// AsText 转换为 WKT 文本 (ST_AsText)
+// SELECT ST_AsText(location) FROM stores;
+func (f GeometryField[T]) AsText() StringExpr[string] {
+	return f.expr.AsText()
+}
+
+// AsBinary 转换为 WKB (ST_AsBinary)
+// SELECT ST_AsBinary(location) FROM stores;
+func (f GeometryField[T]) AsBinary() ScalarExpr[[]byte] {
+	return f.expr.AsBinary()
+}
+
+// SRID 返回空间参考系标识 (ST_SRID)
+// SELECT ST_SRID(location) FROM stores;
+func (f GeometryField[T]) SRID() IntExpr[uint32] {
+	return f.expr.SRID()
+}
+
+// Latitude 返回点的纬度 (ST_Latitude)，只适用于地理坐标系下的点
+// SELECT ST_Latitude(location) FROM stores;
+func (f GeometryField[T]) Latitude() FloatExpr[float64] {
+	return f.expr.Latitude()
+}
+
+// Longitude 返回点的经度 (ST_Longitude)，只适用于地理坐标系下的点
+// SELECT ST_Longitude(location) FROM stores;
+func (f GeometryField[T]) Longitude() FloatExpr[float64] {
+	return f.expr.Longitude()
+}
+
+// Distance 两个几何值之间的距离 (ST_Distance)，地理坐标系下单位为米
+// SELECT ST_Distance(a.location, b.location) FROM ...;
+func (f GeometryField[T]) Distance(other GeometryInput) FloatExpr[float64] {
+	return f.expr.Distance(other)
+}
+
+// DistanceSphere 两点在球面上的最短距离，单位为米 (ST_Distance_Sphere)
+// SELECT * FROM stores WHERE ST_Distance_Sphere(location, ST_SRID(POINT(121.47, 31.23), 4326)) < 5000;
+// 示例: s.Location.DistanceSphere(gsql.LatLng(31.23, 121.47)).Lt(5000)
+func (f GeometryField[T]) DistanceSphere(other GeometryInput) FloatExpr[float64] {
+	return f.expr.DistanceSphere(other)
+}
+
+// Contains 当前几何值是否完全包含 other (ST_Contains)
+// SELECT * FROM regions WHERE ST_Contains(area, ST_GeomFromText('POINT(1 1)'));
+func (f GeometryField[T]) Contains(other GeometryInput) Condition {
+	return f.expr.Contains(other)
+}
+
+// Within 当前几何值是否完全位于 other 内 (ST_Within)
+// SELECT * FROM stores WHERE ST_Within(location, ST_GeomFromText('POLYGON(...)', 4326));
+func (f GeometryField[T]) Within(other GeometryInput) Condition {
+	return f.expr.Within(other)
+}
+
+// Intersects 两个几何值是否相交 (ST_Intersects)
+func (f GeometryField[T]) Intersects(other GeometryInput) Condition {
+	return f.expr.Intersects(other)
+}
+
+// Equals 两个几何值在空间上是否相等 (ST_Equals)
+func (f GeometryField[T]) Equals(other GeometryInput) Condition {
+	return f.expr.Equals(other)
+}
+
+// MBRContains 当前几何值的最小外接矩形是否包含 other 的最小外接矩形 (MBRContains)，可以使用空间索引
+// SELECT * FROM regions WHERE MBRContains(area, ST_GeomFromText('POINT(1 1)'));
+func (f GeometryField[T]) MBRContains(other GeometryInput) Condition {
+	return f.expr.MBRContains(other)
+}
+
+// MBRWithin 当前几何值的最小外接矩形是否位于 other 的最小外接矩形内 (MBRWithin)
+func (f GeometryField[T]) MBRWithin(other GeometryInput) Condition {
+	return f.expr.MBRWithin(other)
+}
+
+// Buffer 返回到当前几何值距离不超过 distance 的区域 (ST_Buffer)
+// 地理坐标系下 distance 单位为米，仅支持点（MySQL 8.0.26+）
+// SELECT ST_Buffer(location, 500) FROM stores;
+func (f GeometryField[T]) Buffer(distance float64) GeometryExpr[Polygon] {
+	return f.expr.Buffer(distance)
+}
+
+// TypeFamily 返回类型族 "Geometry"，用于 INSERT 目标列与插入数据的类型校验
+func (f GeometryField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
+// Count 计算非NULL值的数量 (COUNT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(id) FROM users;
+// SELECT status, COUNT(id) FROM orders GROUP BY status;
+func (f GeometryField[T]) Count() IntExpr[int64] {
+	return f.expr.Count()
+}
+
+// CountDistinct 计算不重复非NULL值的数量 (COUNT DISTINCT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(DISTINCT status) FROM orders;
+// SELECT user_id, COUNT(DISTINCT product_id) FROM cart GROUP BY user_id;
+func (f GeometryField[T]) CountDistinct() IntExpr[int64] {
+	return f.expr.CountDistinct()
+}
+
+// IfNull 如果表达式为NULL则返回默认值
+// 内部使用 COALESCE 实现，等价于 Coalesce(defaultValue)
+func (f GeometryField[T]) IfNull(defaultValue any) GeometryExpr[T] {
+	return f.expr.IfNull(defaultValue)
+}
+
+// Coalesce 返回参数列表中第一个非NULL的值 (COALESCE)
+// 数据库支持: MySQL, PostgreSQL, SQLite (SQL 标准函数)
+// SELECT COALESCE(nickname, username, 'Anonymous') FROM users;
+func (f GeometryField[T]) Coalesce(values ...any) GeometryExpr[T] {
+	return f.expr.Coalesce(values...)
+}
+
+// NullIf 如果两个表达式相等则返回NULL，否则返回第一个表达式 (NULLIF)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT NULLIF(username, ") FROM users; -- 空字符串转为NULL
+func (f GeometryField[T]) NullIf(value any) GeometryExpr[T] {
+	return f.expr.NullIf(value)
+}
+
+func (f GeometryField[T]) IsNull() Condition {
+	return f.expr.IsNull()
+}
+
+func (f GeometryField[T]) IsNotNull() Condition {
+	return f.expr.IsNotNull()
+}
+
+// ==================== BoolField ====================
+
+type BoolField[T any] struct {
+	expr   BoolExpr[T]
+	flags  types.FieldFlag
+	column *clauses2.ColumnQuote
+}
+
+func BoolFieldOf[T any](tableName, name string, flags ...types.FieldFlag) BoolField[T] {
+	q := &clauses2.ColumnQuote{
+		TableName:  tableName,
+		ColumnName: name,
+		Alias:      "",
+	}
+	ret := BoolField[T]{
+		expr:   BoolOf[T](q),
+		column: q,
+		flags:  0,
+	}
+	if len(flags) > 0 {
+		ret.flags = flags[0]
+	}
+	return ret
+}
+
+func BoolFieldColumn[T any](name string) BoolFieldColumnBuilder[T] {
+	return BoolFieldColumnBuilder[T]{name: name}
+}
+
+type BoolFieldColumnBuilder[T any] struct {
+	name string
+}
+
+func (b BoolFieldColumnBuilder[T]) From(source interface{ TableName() string }) BoolField[T] {
+	return BoolFieldOf[T](source.TableName(), b.name)
+}
+
+/////////////// base ///////////////
+
+func (f BoolField[T]) Build(builder clause.Builder) {
+	f.expr.Build(builder)
+}
+
+func (f BoolField[T]) ToExpr() clause.Expression {
+	return f.expr
+}
+
+func (f BoolField[T]) Unwrap() clause.Expression {
+	return f.expr
+}
+
+func (f BoolField[T]) Expr() BoolExpr[T] {
+	return f.expr
+}
+
+func (f BoolField[T]) Apply(functionName FunctionName) BoolExpr[T] {
+	var expr = f.expr.Unwrap()
+	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return BoolOf[T](v.RowValue())
+		}
+		v.NoAS()
+	}
+	e := clause.Expr{
+		SQL:  string(functionName) + "(?)",
+		Vars: []any{expr},
+	}
+	return BoolOf[T](e)
+}
+
+/////////////// column-name ///////////////
+
+// TableName 返回表名
+func (f BoolField[T]) TableName() string {
+	return f.column.TableName
+}
+
+// ColumnName 返回列名
+func (f BoolField[T]) ColumnName() string {
+	return f.column.ColumnName
+}
+
+// Name 返回字段名称
+// 对于expr，返回别名
+// 对于普通字段，有别名的返回别名，否则返回真实名字
+func (f BoolField[T]) Name() string {
+	return f.column.Name()
+}
+
+func (f BoolField[T]) Alias() string {
+	return f.column.Alias
+}
+
+func (f BoolField[T]) FullName() string {
+	return f.column.FullName()
+}
+
+func (f BoolField[T]) As(alias string) fieldi.IField {
+	return f.WithAlias(alias)
+}
+
+func (f BoolField[T]) WithAlias(alias string) BoolField[T] {
+	ret := BoolFieldOf[T](f.TableName(), f.ColumnName(), f.flags)
+	ret.column.Alias = alias
+	return ret
+}
+
+func (f BoolField[T]) WithTable(tableName interface{ TableName() string }, fieldNames ...string) BoolField[T] {
+	name := f.ColumnName()
+	if len(fieldNames) > 0 {
+		name = fieldNames[0]
+	}
+	return BoolFieldOf[T](tableName.TableName(), name, f.flags)
+}
+
+/////////////// flags ///////////////
+
+func (f BoolField[T]) FieldType() T {
+	var def T
+	return def
+}
+
+func (f BoolField[T]) Flags() types.FieldFlag {
+	return f.flags
+}
+
+func (f BoolField[T]) HasFlag(flag types.FieldFlag) bool {
+	return f.flags&flag != 0
+}
+
+func (f BoolField[T]) IsPrimaryKey() bool {
+	return f.HasFlag(types.FlagPrimaryKey)
+}
+
+func (f BoolField[T]) IsUniqueIndex() bool {
+	return f.HasFlag(types.FlagUniqueIndex)
+}
+
+/////////////// asc/desc ///////////////
+
+func (f BoolField[T]) Asc() types.OrderItem {
+	return types.NewOrder(f, true)
+}
+
+func (f BoolField[T]) Desc() types.OrderItem {
+	return types.NewOrder(f, false)
+}
+
+/////////////// re-exported methods from BoolExpr ///////////////
+
+// IsTrue 值为真 (IS TRUE)，NULL 不满足条件
+// SELECT * FROM employees WHERE is_active IS TRUE;
+func (f BoolField[T]) IsTrue() Condition {
+	return f.expr.IsTrue()
+}
+
+// IsFalse 值为假 (IS FALSE)，NULL 不满足条件
+// SELECT * FROM employees WHERE is_active IS FALSE;
+func (f BoolField[T]) IsFalse() Condition {
+	return f.expr.IsFalse()
+}
+
+// Not 取反 (NOT)
+// SELECT * FROM employees WHERE NOT is_active;
+func (f BoolField[T]) Not() BoolExpr[T] {
+	return f.expr.Not()
+}
+
+// And 与其它条件组合 (AND)，空条件会被忽略
+// SELECT * FROM employees WHERE is_active AND salary > 5000;
+func (f BoolField[T]) And(others ...clause.Expression) Condition {
+	return f.expr.And(others...)
+}
+
+// Or 与其它条件组合 (OR)，空条件会被忽略
+// SELECT * FROM employees WHERE is_active OR department = 'HR';
+func (f BoolField[T]) Or(others ...clause.Expression) Condition {
+	return f.expr.Or(others...)
+}
+
+// CountIf 统计值为真的行数 (COUNT(CASE WHEN ... THEN 1 END))
+// SELECT department, COUNT(CASE WHEN is_active THEN 1 END) FROM employees GROUP BY department;
+func (f BoolField[T]) CountIf() IntExpr[int64] {
+	return f.expr.CountIf()
+}
+
+// Sum 值为真的行计 1，否则计 0 后求和 (SUM(CASE WHEN ... THEN 1 ELSE 0 END))，兼容不支持对布尔值求和的数据库
+// SELECT SUM(CASE WHEN is_active THEN 1 ELSE 0 END) FROM employees;
+func (f BoolField[T]) Sum() IntExpr[int64] {
+	return f.expr.Sum()
+}
+
+// TypeFamily 返回类型族 "Bool"，用于 INSERT 目标列与插入数据的类型校验
+func (f BoolField[T]) TypeFamily() string {
+	return f.expr.TypeFamily()
+}
+
+// Count 计算非NULL值的数量 (COUNT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(id) FROM users;
+// SELECT status, COUNT(id) FROM orders GROUP BY status;
+func (f BoolField[T]) Count() IntExpr[int64] {
+	return f.expr.Count()
+}
+
+// CountDistinct 计算不重复非NULL值的数量 (COUNT DISTINCT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(DISTINCT status) FROM orders;
+// SELECT user_id, COUNT(DISTINCT product_id) FROM cart GROUP BY user_id;
+func (f BoolField[T]) CountDistinct() IntExpr[int64] {
+	return f.expr.CountDistinct()
+}
+
+// IfNull 如果表达式为NULL则返回默认值
+// 内部使用 COALESCE 实现，等价于 Coalesce(defaultValue)
+func (f BoolField[T]) IfNull(defaultValue any) BoolExpr[T] {
+	return f.expr.IfNull(defaultValue)
+}
+
+// Coalesce 返回参数列表中第一个非NULL的值 (COALESCE)
+// 数据库支持: MySQL, PostgreSQL, SQLite (SQL 标准函数)
+// SELECT COALESCE(nickname, username, 'Anonymous') FROM users;
+func (f BoolField[T]) Coalesce(values ...any) BoolExpr[T] {
+	return f.expr.Coalesce(values...)
+}
+
+// NullIf 如果两个表达式相等则返回NULL，否则返回第一个表达式 (NULLIF)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT NULLIF(username, ") FROM users; -- 空字符串转为NULL
+func (f BoolField[T]) NullIf(value any) BoolExpr[T] {
+	return f.expr.NullIf(value)
+}
+
+func (f BoolField[T]) Eq(value T) Condition {
+	return f.expr.Eq(value)
+}
+
+func (f BoolField[T]) EqF(other clause.Expression) Condition {
+	return f.expr.EqF(other)
+}
+
+func (f BoolField[T]) EqOpt(value mo.Option[T]) Condition {
+	return f.expr.EqOpt(value)
+}
+
+func (f BoolField[T]) NotF(other clause.Expression) Condition {
+	return f.expr.NotF(other)
+}
+
+func (f BoolField[T]) NotOpt(value mo.Option[T]) Condition {
+	return f.expr.NotOpt(value)
+}
+
+func (f BoolField[T]) In(values ...T) Condition {
+	return f.expr.In(values...)
+}
+
+func (f BoolField[T]) NotIn(values ...T) Condition {
+	return f.expr.NotIn(values...)
+}
+
+// InSubquery 用于子查询的 IN 条件
+// 示例: WHERE id IN (SELECT customer_id FROM orders)
+func (f BoolField[T]) InSubquery(subquery clause.Expression) Condition {
+	return f.expr.InSubquery(subquery)
+}
+
+// NotInSubquery 用于子查询的 NOT IN 条件
+// 示例: WHERE id NOT IN (SELECT customer_id FROM orders)
+func (f BoolField[T]) NotInSubquery(subquery clause.Expression) Condition {
+	return f.expr.NotInSubquery(subquery)
+}
+
+func (f BoolField[T]) IsNull() Condition {
+	return f.expr.IsNull()
+}
+
+func (f BoolField[T]) IsNotNull() Condition {
+	return f.expr.IsNotNull()
+}
+
+// ==================== EnumField ====================
+
+type EnumField[T EnumValue[T]] struct {
+	expr   ScalarExpr[T]
+	flags  types.FieldFlag
+	column *clauses2.ColumnQuote
+}
+
+func EnumFieldOf[T EnumValue[T]](tableName, name string, flags ...types.FieldFlag) EnumField[T] {
+	q := &clauses2.ColumnQuote{
+		TableName:  tableName,
+		ColumnName: name,
+		Alias:      "",
+	}
+	ret := EnumField[T]{
+		expr:   ScalarOf[T](q),
+		column: q,
+		flags:  0,
+	}
+	if len(flags) > 0 {
+		ret.flags = flags[0]
+	}
+	return ret
+}
+
+func EnumColumn[T EnumValue[T]](name string) EnumColumnBuilder[T] {
+	return EnumColumnBuilder[T]{name: name}
+}
+
+type EnumColumnBuilder[T EnumValue[T]] struct {
+	name string
+}
+
+func (b EnumColumnBuilder[T]) From(source interface{ TableName() string }) EnumField[T] {
+	return EnumFieldOf[T](source.TableName(), b.name)
+}
+
+/////////////// base ///////////////
+
+func (f EnumField[T]) Build(builder clause.Builder) {
+	f.expr.Build(builder)
+}
+
+func (f EnumField[T]) ToExpr() clause.Expression {
+	return f.expr
+}
+
+func (f EnumField[T]) Unwrap() clause.Expression {
+	return f.expr
+}
+
+func (f EnumField[T]) Expr() ScalarExpr[T] {
+	return f.expr
+}
+
+func (f EnumField[T]) Apply(functionName FunctionName) ScalarExpr[T] {
+	var expr = f.expr.Unwrap()
+	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return ScalarOf[T](v.RowValue())
+		}
+		v.NoAS()
+	}
+	e := clause.Expr{
+		SQL:  string(functionName) + "(?)",
+		Vars: []any{expr},
+	}
+	return ScalarOf[T](e)
+}
+
+/////////////// column-name ///////////////
+
+// TableName 返回表名
+func (f EnumField[T]) TableName() string {
+	return f.column.TableName
+}
+
+// ColumnName 返回列名
+func (f EnumField[T]) ColumnName() string {
+	return f.column.ColumnName
+}
+
+// Name 返回字段名称
+// 对于expr，返回别名
+// 对于普通字段，有别名的返回别名，否则返回真实名字
+func (f EnumField[T]) Name() string {
+	return f.column.Name()
+}
+
+func (f EnumField[T]) Alias() string {
+	return f.column.Alias
+}
+
+func (f EnumField[T]) FullName() string {
+	return f.column.FullName()
+}
+
+func (f EnumField[T]) As(alias string) fieldi.IField {
+	return f.WithAlias(alias)
+}
+
+func (f EnumField[T]) WithAlias(alias string) EnumField[T] {
+	ret := EnumFieldOf[T](f.TableName(), f.ColumnName(), f.flags)
+	ret.column.Alias = alias
+	return ret
+}
+
+func (f EnumField[T]) WithTable(tableName interface{ TableName() string }, fieldNames ...string) EnumField[T] {
+	name := f.ColumnName()
+	if len(fieldNames) > 0 {
+		name = fieldNames[0]
+	}
+	return EnumFieldOf[T](tableName.TableName(), name, f.flags)
+}
+
+/////////////// flags ///////////////
+
+func (f EnumField[T]) FieldType() T {
+	var def T
+	return def
+}
+
+func (f EnumField[T]) Flags() types.FieldFlag {
+	return f.flags
+}
+
+func (f EnumField[T]) HasFlag(flag types.FieldFlag) bool {
+	return f.flags&flag != 0
+}
+
+func (f EnumField[T]) IsPrimaryKey() bool {
+	return f.HasFlag(types.FlagPrimaryKey)
+}
+
+func (f EnumField[T]) IsUniqueIndex() bool {
+	return f.HasFlag(types.FlagUniqueIndex)
+}
+
+/////////////// asc/desc ///////////////
+
+func (f EnumField[T]) Asc() types.OrderItem {
+	return types.NewOrder(f, true)
+}
+
+func (f EnumField[T]) Desc() types.OrderItem {
+	return types.NewOrder(f, false)
+}
+
+/////////////// re-exported methods from ScalarExpr ///////////////
+
+func (f EnumField[T]) ToString() StringExpr[T] {
+	return f.expr.ToString()
+}
+
+func (f EnumField[T]) ToInt() IntExpr[T] {
+	return f.expr.ToInt()
+}
+
+func (f EnumField[T]) ToFloat() FloatExpr[T] {
+	return f.expr.ToFloat()
+}
+
+func (f EnumField[T]) ToDecimal() DecimalExpr[T] {
+	return f.expr.ToDecimal()
+}
+
+func (f EnumField[T]) ToTime() TimeExpr[T] {
+	return f.expr.ToTime()
+}
+
+func (f EnumField[T]) ToDate() DateExpr[T] {
+	return f.expr.ToDate()
+}
+
+// Count 计算非NULL值的数量 (COUNT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(id) FROM users;
+// SELECT status, COUNT(id) FROM orders GROUP BY status;
+func (f EnumField[T]) Count() IntExpr[int64] {
+	return f.expr.Count()
+}
+
+// CountDistinct 计算不重复非NULL值的数量 (COUNT DISTINCT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(DISTINCT status) FROM orders;
+// SELECT user_id, COUNT(DISTINCT product_id) FROM cart GROUP BY user_id;
+func (f EnumField[T]) CountDistinct() IntExpr[int64] {
+	return f.expr.CountDistinct()
+}
+
+// IfNull 如果表达式为NULL则返回默认值
+// 内部使用 COALESCE 实现，等价于 Coalesce(defaultValue)
+func (f EnumField[T]) IfNull(defaultValue any) ScalarExpr[T] {
+	return f.expr.IfNull(defaultValue)
+}
+
+// Coalesce 返回参数列表中第一个非NULL的值 (COALESCE)
+// 数据库支持: MySQL, PostgreSQL, SQLite (SQL 标准函数)
+// SELECT COALESCE(nickname, username, 'Anonymous') FROM users;
+func (f EnumField[T]) Coalesce(values ...any) ScalarExpr[T] {
+	return f.expr.Coalesce(values...)
+}
+
+// NullIf 如果两个表达式相等则返回NULL，否则返回第一个表达式 (NULLIF)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT NULLIF(username, ") FROM users; -- 空字符串转为NULL
+func (f EnumField[T]) NullIf(value any) ScalarExpr[T] {
+	return f.expr.NullIf(value)
+}
+
+func (f EnumField[T]) EqF(other clause.Expression) Condition {
+	return f.expr.EqF(other)
+}
+
+func (f EnumField[T]) NotF(other clause.Expression) Condition {
+	return f.expr.NotF(other)
+}
+
+// InSubquery 用于子查询的 IN 条件
+// 示例: WHERE id IN (SELECT customer_id FROM orders)
+func (f EnumField[T]) InSubquery(subquery clause.Expression) Condition {
+	return f.expr.InSubquery(subquery)
+}
+
+// NotInSubquery 用于子查询的 NOT IN 条件
+// 示例: WHERE id NOT IN (SELECT customer_id FROM orders)
+func (f EnumField[T]) NotInSubquery(subquery clause.Expression) Condition {
+	return f.expr.NotInSubquery(subquery)
+}
+
+func (f EnumField[T]) IsNull() Condition {
+	return f.expr.IsNull()
+}
+
+func (f EnumField[T]) IsNotNull() Condition {
+	return f.expr.IsNotNull()
+}
+
+// ==================== UUIDField ====================
+
+type UUIDField[T UUIDValue] struct {
+	expr   ScalarExpr[T]
+	flags  types.FieldFlag
+	column *clauses2.ColumnQuote
+	noSwap bool
+}
+
+func UUIDFieldOf[T UUIDValue](tableName, name string, flags ...types.FieldFlag) UUIDField[T] {
+	q := &clauses2.ColumnQuote{
+		TableName:  tableName,
+		ColumnName: name,
+		Alias:      "",
+	}
+	ret := UUIDField[T]{
+		expr:   ScalarOf[T](q),
+		column: q,
+		flags:  0,
+	}
+	if len(flags) > 0 {
+		ret.flags = flags[0]
+	}
+	return ret
+}
+
+func UUIDColumn[T UUIDValue](name string) UUIDColumnBuilder[T] {
+	return UUIDColumnBuilder[T]{name: name}
+}
+
+type UUIDColumnBuilder[T UUIDValue] struct {
+	name string
+}
+
+func (b UUIDColumnBuilder[T]) From(source interface{ TableName() string }) UUIDField[T] {
+	return UUIDFieldOf[T](source.TableName(), b.name)
+}
+
+/////////////// base ///////////////
+
+func (f UUIDField[T]) Build(builder clause.Builder) {
+	f.expr.Build(builder)
+}
+
+func (f UUIDField[T]) ToExpr() clause.Expression {
+	return f.expr
+}
+
+func (f UUIDField[T]) Unwrap() clause.Expression {
+	return f.expr
+}
+
+func (f UUIDField[T]) Expr() ScalarExpr[T] {
+	return f.expr
+}
+
+func (f UUIDField[T]) Apply(functionName FunctionName) ScalarExpr[T] {
+	var expr = f.expr.Unwrap()
+	if v, ok := expr.(*clauses2.ColumnQuote); ok {
+		if functionName == VALUES {
+			return ScalarOf[T](v.RowValue())
+		}
+		v.NoAS()
+	}
+	e := clause.Expr{
+		SQL:  string(functionName) + "(?)",
+		Vars: []any{expr},
+	}
+	return ScalarOf[T](e)
+}
+
+/////////////// column-name ///////////////
+
+// TableName 返回表名
+func (f UUIDField[T]) TableName() string {
+	return f.column.TableName
+}
+
+// ColumnName 返回列名
+func (f UUIDField[T]) ColumnName() string {
+	return f.column.ColumnName
+}
+
+// Name 返回字段名称
+// 对于expr，返回别名
+// 对于普通字段，有别名的返回别名，否则返回真实名字
+func (f UUIDField[T]) Name() string {
+	return f.column.Name()
+}
+
+func (f UUIDField[T]) Alias() string {
+	return f.column.Alias
+}
+
+func (f UUIDField[T]) FullName() string {
+	return f.column.FullName()
+}
+
+func (f UUIDField[T]) As(alias string) fieldi.IField {
+	return f.WithAlias(alias)
+}
+
+func (f UUIDField[T]) WithAlias(alias string) UUIDField[T] {
+	ret := UUIDFieldOf[T](f.TableName(), f.ColumnName(), f.flags)
+	ret.noSwap = f.noSwap
+	ret.column.Alias = alias
+	return ret
+}
+
+func (f UUIDField[T]) WithTable(tableName interface{ TableName() string }, fieldNames ...string) UUIDField[T] {
+	name := f.ColumnName()
+	if len(fieldNames) > 0 {
+		name = fieldNames[0]
+	}
+	ret := UUIDFieldOf[T](tableName.TableName(), name, f.flags)
+	ret.noSwap = f.noSwap
+	return ret
+}
+
+/////////////// flags ///////////////
+
+func (f UUIDField[T]) FieldType() T {
+	var def T
+	return def
+}
+
+func (f UUIDField[T]) Flags() types.FieldFlag {
+	return f.flags
+}
+
+func (f UUIDField[T]) HasFlag(flag types.FieldFlag) bool {
+	return f.flags&flag != 0
+}
+
+func (f UUIDField[T]) IsPrimaryKey() bool {
+	return f.HasFlag(types.FlagPrimaryKey)
+}
+
+func (f UUIDField[T]) IsUniqueIndex() bool {
+	return f.HasFlag(types.FlagUniqueIndex)
+}
+
+/////////////// asc/desc ///////////////
+
+func (f UUIDField[T]) Asc() types.OrderItem {
+	return types.NewOrder(f, true)
+}
+
+func (f UUIDField[T]) Desc() types.OrderItem {
+	return types.NewOrder(f, false)
+}
+
+/////////////// re-exported methods from ScalarExpr ///////////////
+
+// Count 计算非NULL值的数量 (COUNT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(id) FROM users;
+// SELECT status, COUNT(id) FROM orders GROUP BY status;
+func (f UUIDField[T]) Count() IntExpr[int64] {
+	return f.expr.Count()
+}
+
+// CountDistinct 计算不重复非NULL值的数量 (COUNT DISTINCT)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT COUNT(DISTINCT status) FROM orders;
+// SELECT user_id, COUNT(DISTINCT product_id) FROM cart GROUP BY user_id;
+func (f UUIDField[T]) CountDistinct() IntExpr[int64] {
+	return f.expr.CountDistinct()
+}
+
+// IfNull 如果表达式为NULL则返回默认值
+// 内部使用 COALESCE 实现，等价于 Coalesce(defaultValue)
+func (f UUIDField[T]) IfNull(defaultValue any) ScalarExpr[T] {
+	return f.expr.IfNull(defaultValue)
+}
+
+// Coalesce 返回参数列表中第一个非NULL的值 (COALESCE)
+// 数据库支持: MySQL, PostgreSQL, SQLite (SQL 标准函数)
+// SELECT COALESCE(nickname, username, 'Anonymous') FROM users;
+func (f UUIDField[T]) Coalesce(values ...any) ScalarExpr[T] {
+	return f.expr.Coalesce(values...)
+}
+
+// NullIf 如果两个表达式相等则返回NULL，否则返回第一个表达式 (NULLIF)
+// 数据库支持: MySQL, PostgreSQL, SQLite
+// SELECT NULLIF(username, ") FROM users; -- 空字符串转为NULL
+func (f UUIDField[T]) NullIf(value any) ScalarExpr[T] {
+	return f.expr.NullIf(value)
+}
+
+func (f UUIDField[T]) EqF(other clause.Expression) Condition {
+	return f.expr.EqF(other)
+}
+
+func (f UUIDField[T]) NotF(other clause.Expression) Condition {
+	return f.expr.NotF(other)
+}
+
+// InSubquery 用于子查询的 IN 条件
+// 示例: WHERE id IN (SELECT customer_id FROM orders)
+func (f UUIDField[T]) InSubquery(subquery clause.Expression) Condition {
+	return f.expr.InSubquery(subquery)
+}
+
+// NotInSubquery 用于子查询的 NOT IN 条件
+// 示例: WHERE id NOT IN (SELECT customer_id FROM orders)
+func (f UUIDField[T]) NotInSubquery(subquery clause.Expression) Condition {
+	return f.expr.NotInSubquery(subquery)
+}
+
+func (f UUIDField[T]) IsNull() Condition {
+	return f.expr.IsNull()
+}
+
+func (f UUIDField[T]) IsNotNull() Condition {
+	return f.expr.IsNotNull()
+}
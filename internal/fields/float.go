@@ -104,3 +104,8 @@ func (e FloatExpr[T]) Format(decimals int) StringExpr[string] {
 func (e FloatExpr[T]) Unwrap() clause.Expression {
 	return e.numericComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "Float"，用于 INSERT 目标列与插入数据的类型校验
+func (e FloatExpr[T]) TypeFamily() string {
+	return "Float"
+}
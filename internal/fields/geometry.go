@@ -0,0 +1,175 @@
+package fields
+
+import (
+	"github.com/donutnomad/gsql/clause"
+)
+
+var _ clause.Expression = (*GeometryExpr[Point])(nil)
+
+// ==================== 空间类型系统 ====================
+
+// GeometryInput 几何函数的输入参数
+// GeometryExpr、GeometryField 以及 Point、Polygon 值都实现了此接口
+type GeometryInput interface {
+	clause.Expression
+	geometryInput() // 标记方法，用于类型约束
+}
+
+// GeometryExpr 空间类型表达式，用于 GEOMETRY/POINT/POLYGON 等字段和空间函数的返回值
+// @gentype
+// 数据库支持: MySQL 8.0+
+// 使用场景：
+//   - POINT、POLYGON 等空间类型字段
+//   - ST_GeomFromText、ST_Buffer 等空间函数的返回值
+type GeometryExpr[T any] struct {
+	pointerExprImpl // IsNull, IsNotNull
+	nullCondFuncSql // IfNull, Coalesce, NullIf
+	baseExprSql     // Build, ToExpr, As
+}
+
+func GeometryOf[T any](expr clause.Expression) GeometryExpr[T] {
+	return GeometryExpr[T]{
+		pointerExprImpl: pointerExprImpl{Expression: expr},
+		nullCondFuncSql: nullCondFuncSql{Expression: expr},
+		baseExprSql:     baseExprSql{Expr: expr},
+	}
+}
+
+func GeometryFrom[T any](field interface{ FieldType() T }) GeometryExpr[T] {
+	return GeometryOf[T](anyToExpr(field))
+}
+
+// GeomFromText 从 WKT 创建几何值 (ST_GeomFromText)，坐标按经度在前、纬度在后解析
+// srid 省略时为 0（笛卡尔坐标系）
+// SELECT ST_GeomFromText('POINT(121.4737 31.2304)', 4326, 'axis-order=long-lat');
+// 示例: gsql.GeomFromText("POLYGON((0 0,10 0,10 10,0 10,0 0))", gsql.SRIDWGS84)
+func GeomFromText(wkt string, srid ...uint32) GeometryExpr[[]byte] {
+	return GeometryOf[[]byte](geomFromText(wkt, optionalSRID(srid)))
+}
+
+// GeomFromWKB 从 WKB 创建几何值 (ST_GeomFromWKB)，坐标按经度在前、纬度在后解析
+// SELECT ST_GeomFromWKB(x'0101000000...', 4326, 'axis-order=long-lat');
+func GeomFromWKB(wkb []byte, srid ...uint32) GeometryExpr[[]byte] {
+	if s := optionalSRID(srid); s != 0 {
+		return GeometryOf[[]byte](clause.Expr{
+			SQL:  "ST_GeomFromWKB(?, ?, 'axis-order=long-lat')",
+			Vars: []any{wkb, s},
+		})
+	}
+	return GeometryOf[[]byte](clause.Expr{SQL: "ST_GeomFromWKB(?)", Vars: []any{wkb}})
+}
+
+// LatLng 创建 WGS 84（SRID 4326）坐标系下的点 (ST_SRID(POINT(lng, lat), 4326))
+// SELECT ST_SRID(POINT(121.4737, 31.2304), 4326);
+// 示例: s.Location.DistanceSphere(gsql.LatLng(31.2304, 121.4737)).Lt(5000)
+func LatLng(lat, lng float64) GeometryExpr[Point] {
+	return GeometryOf[Point](clause.Expr{
+		SQL:  "ST_SRID(POINT(?, ?), ?)",
+		Vars: []any{lng, lat, SRIDWGS84},
+	})
+}
+
+func optionalSRID(srid []uint32) uint32 {
+	if len(srid) > 0 {
+		return srid[0]
+	}
+	return 0
+}
+
+func (e GeometryExpr[T]) geometryInput() {}
+
+// ==================== GeometryExpr 方法 ====================
+
+// AsText 转换为 WKT 文本 (ST_AsText)
+// SELECT ST_AsText(location) FROM stores;
+func (e GeometryExpr[T]) AsText() StringExpr[string] {
+	return StringOf[string](clause.Expr{SQL: "ST_AsText(?)", Vars: []any{e.Unwrap()}})
+}
+
+// AsBinary 转换为 WKB (ST_AsBinary)
+// SELECT ST_AsBinary(location) FROM stores;
+func (e GeometryExpr[T]) AsBinary() ScalarExpr[[]byte] {
+	return ScalarOf[[]byte](clause.Expr{SQL: "ST_AsBinary(?)", Vars: []any{e.Unwrap()}})
+}
+
+// SRID 返回空间参考系标识 (ST_SRID)
+// SELECT ST_SRID(location) FROM stores;
+func (e GeometryExpr[T]) SRID() IntExpr[uint32] {
+	return IntOf[uint32](clause.Expr{SQL: "ST_SRID(?)", Vars: []any{e.Unwrap()}})
+}
+
+// Latitude 返回点的纬度 (ST_Latitude)，只适用于地理坐标系下的点
+// SELECT ST_Latitude(location) FROM stores;
+func (e GeometryExpr[T]) Latitude() FloatExpr[float64] {
+	return FloatOf[float64](clause.Expr{SQL: "ST_Latitude(?)", Vars: []any{e.Unwrap()}})
+}
+
+// Longitude 返回点的经度 (ST_Longitude)，只适用于地理坐标系下的点
+// SELECT ST_Longitude(location) FROM stores;
+func (e GeometryExpr[T]) Longitude() FloatExpr[float64] {
+	return FloatOf[float64](clause.Expr{SQL: "ST_Longitude(?)", Vars: []any{e.Unwrap()}})
+}
+
+// Distance 两个几何值之间的距离 (ST_Distance)，地理坐标系下单位为米
+// SELECT ST_Distance(a.location, b.location) FROM ...;
+func (e GeometryExpr[T]) Distance(other GeometryInput) FloatExpr[float64] {
+	return FloatOf[float64](clause.Expr{SQL: "ST_Distance(?, ?)", Vars: []any{e.Unwrap(), other}})
+}
+
+// DistanceSphere 两点在球面上的最短距离，单位为米 (ST_Distance_Sphere)
+// SELECT * FROM stores WHERE ST_Distance_Sphere(location, ST_SRID(POINT(121.47, 31.23), 4326)) < 5000;
+// 示例: s.Location.DistanceSphere(gsql.LatLng(31.23, 121.47)).Lt(5000)
+func (e GeometryExpr[T]) DistanceSphere(other GeometryInput) FloatExpr[float64] {
+	return FloatOf[float64](clause.Expr{SQL: "ST_Distance_Sphere(?, ?)", Vars: []any{e.Unwrap(), other}})
+}
+
+// Contains 当前几何值是否完全包含 other (ST_Contains)
+// SELECT * FROM regions WHERE ST_Contains(area, ST_GeomFromText('POINT(1 1)'));
+func (e GeometryExpr[T]) Contains(other GeometryInput) Condition {
+	return Condition{clause.Expr{SQL: "ST_Contains(?, ?)", Vars: []any{e.Unwrap(), other}}}
+}
+
+// Within 当前几何值是否完全位于 other 内 (ST_Within)
+// SELECT * FROM stores WHERE ST_Within(location, ST_GeomFromText('POLYGON(...)', 4326));
+func (e GeometryExpr[T]) Within(other GeometryInput) Condition {
+	return Condition{clause.Expr{SQL: "ST_Within(?, ?)", Vars: []any{e.Unwrap(), other}}}
+}
+
+// Intersects 两个几何值是否相交 (ST_Intersects)
+func (e GeometryExpr[T]) Intersects(other GeometryInput) Condition {
+	return Condition{clause.Expr{SQL: "ST_Intersects(?, ?)", Vars: []any{e.Unwrap(), other}}}
+}
+
+// Equals 两个几何值在空间上是否相等 (ST_Equals)
+func (e GeometryExpr[T]) Equals(other GeometryInput) Condition {
+	return Condition{clause.Expr{SQL: "ST_Equals(?, ?)", Vars: []any{e.Unwrap(), other}}}
+}
+
+// MBRContains 当前几何值的最小外接矩形是否包含 other 的最小外接矩形 (MBRContains)，可以使用空间索引
+// SELECT * FROM regions WHERE MBRContains(area, ST_GeomFromText('POINT(1 1)'));
+func (e GeometryExpr[T]) MBRContains(other GeometryInput) Condition {
+	return Condition{clause.Expr{SQL: "MBRContains(?, ?)", Vars: []any{e.Unwrap(), other}}}
+}
+
+// MBRWithin 当前几何值的最小外接矩形是否位于 other 的最小外接矩形内 (MBRWithin)
+func (e GeometryExpr[T]) MBRWithin(other GeometryInput) Condition {
+	return Condition{clause.Expr{SQL: "MBRWithin(?, ?)", Vars: []any{e.Unwrap(), other}}}
+}
+
+// Buffer 返回到当前几何值距离不超过 distance 的区域 (ST_Buffer)
+// 地理坐标系下 distance 单位为米，仅支持点（MySQL 8.0.26+）
+// SELECT ST_Buffer(location, 500) FROM stores;
+func (e GeometryExpr[T]) Buffer(distance float64) GeometryExpr[Polygon] {
+	return GeometryOf[Polygon](clause.Expr{SQL: "ST_Buffer(?, ?)", Vars: []any{e.Unwrap(), distance}})
+}
+
+func (e GeometryExpr[T]) Unwrap() clause.Expression {
+	return e.pointerExprImpl.Expression
+}
+
+func (f GeometryField[T]) geometryInput() {}
+
+// TypeFamily 返回类型族 "Geometry"，用于 INSERT 目标列与插入数据的类型校验
+func (e GeometryExpr[T]) TypeFamily() string {
+	return "Geometry"
+}
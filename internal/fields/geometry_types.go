@@ -0,0 +1,255 @@
+package fields
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/donutnomad/gsql/clause"
+	"gorm.io/gorm"
+	gormclause "gorm.io/gorm/clause"
+)
+
+// SRIDWGS84 WGS 84 经纬度坐标系
+const SRIDWGS84 uint32 = 4326
+
+const (
+	wkbPoint   uint32 = 1
+	wkbPolygon uint32 = 3
+)
+
+var (
+	_ GeometryInput = Point{}
+	_ GeometryInput = Polygon{}
+)
+
+// Point 点，可直接作为 POINT 列的字段类型读写，也可以作为几何函数的参数
+// 坐标按经度/纬度保存，写入时使用 axis-order=long-lat，不受坐标系轴顺序的影响
+// 示例:
+//
+//	type Store struct {
+//		ID       int64
+//		Location gsql.Point `gorm:"type:point srid 4326"`
+//	}
+//	store.Location = gsql.NewPoint(31.2304, 121.4737)
+type Point struct {
+	Lng  float64 // 经度（X）
+	Lat  float64 // 纬度（Y）
+	SRID uint32
+}
+
+// NewPoint 创建 WGS 84（SRID 4326）坐标系下的点
+func NewPoint(lat, lng float64) Point {
+	return Point{Lng: lng, Lat: lat, SRID: SRIDWGS84}
+}
+
+// WKT 返回 WKT 表示，如 POINT(121.4737 31.2304)
+func (p Point) WKT() string {
+	return "POINT(" + p.coords() + ")"
+}
+
+func (p Point) coords() string {
+	return strconv.FormatFloat(p.Lng, 'f', -1, 64) + " " + strconv.FormatFloat(p.Lat, 'f', -1, 64)
+}
+
+func (p Point) Build(builder clause.Builder) {
+	geomFromText(p.WKT(), p.SRID).Build(builder)
+}
+
+func (p Point) GormValue(_ context.Context, _ *gorm.DB) gormclause.Expr {
+	return geomFromText(p.WKT(), p.SRID).Compat()
+}
+
+func (Point) GormDataType() string {
+	return "point"
+}
+
+// Scan 读取 MySQL 几何值的内部格式（4 字节 SRID + WKB）
+func (p *Point) Scan(src any) error {
+	srid, r, err := newGeometryReader(src)
+	if err != nil || r == nil {
+		*p = Point{}
+		return err
+	}
+	if err := r.expectType(wkbPoint); err != nil {
+		return err
+	}
+	*p, err = r.point()
+	p.SRID = srid
+	return err
+}
+
+func (p Point) geometryInput() {}
+
+// Polygon 多边形，第一个环为外环，其余为内环（洞），每个环首尾点相同
+type Polygon struct {
+	Rings [][]Point
+	SRID  uint32
+}
+
+// WKT 返回 WKT 表示，如 POLYGON((0 0,1 0,1 1,0 0))
+func (p Polygon) WKT() string {
+	var sb strings.Builder
+	sb.WriteString("POLYGON(")
+	for i, ring := range p.Rings {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("(")
+		for j, pt := range ring {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(pt.coords())
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func (p Polygon) Build(builder clause.Builder) {
+	geomFromText(p.WKT(), p.SRID).Build(builder)
+}
+
+func (p Polygon) GormValue(_ context.Context, _ *gorm.DB) gormclause.Expr {
+	return geomFromText(p.WKT(), p.SRID).Compat()
+}
+
+func (Polygon) GormDataType() string {
+	return "polygon"
+}
+
+// Scan 读取 MySQL 几何值的内部格式（4 字节 SRID + WKB）
+func (p *Polygon) Scan(src any) error {
+	srid, r, err := newGeometryReader(src)
+	if err != nil || r == nil {
+		*p = Polygon{}
+		return err
+	}
+	if err := r.expectType(wkbPolygon); err != nil {
+		return err
+	}
+	numRings, err := r.readCount(4)
+	if err != nil {
+		return err
+	}
+	rings := make([][]Point, 0, numRings)
+	for range numRings {
+		numPoints, err := r.readCount(16)
+		if err != nil {
+			return err
+		}
+		ring := make([]Point, 0, numPoints)
+		for range numPoints {
+			pt, err := r.point()
+			if err != nil {
+				return err
+			}
+			pt.SRID = srid
+			ring = append(ring, pt)
+		}
+		rings = append(rings, ring)
+	}
+	*p = Polygon{Rings: rings, SRID: srid}
+	return nil
+}
+
+func (p Polygon) geometryInput() {}
+
+// geomFromText ST_GeomFromText(wkt[, srid, 'axis-order=long-lat'])
+func geomFromText(wkt string, srid uint32) clause.Expr {
+	if srid == 0 {
+		return clause.Expr{SQL: "ST_GeomFromText(?)", Vars: []any{wkt}}
+	}
+	return clause.Expr{SQL: "ST_GeomFromText(?, ?, 'axis-order=long-lat')", Vars: []any{wkt, srid}}
+}
+
+// ==================== WKB 解析 ====================
+
+var errInvalidGeometry = errors.New("invalid geometry value")
+
+type geometryReader struct {
+	buf   []byte
+	order binary.ByteOrder
+}
+
+// newGeometryReader 解析 SRID 前缀，src 为 nil 时返回 nil reader
+func newGeometryReader(src any) (uint32, *geometryReader, error) {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		return 0, nil, nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return 0, nil, fmt.Errorf("cannot scan %T into geometry", src)
+	}
+	if len(b) < 4+1+4 {
+		return 0, nil, fmt.Errorf("%w: %d bytes", errInvalidGeometry, len(b))
+	}
+	srid := binary.LittleEndian.Uint32(b)
+	return srid, &geometryReader{buf: b[4:]}, nil
+}
+
+// expectType 读取 WKB 的字节序和几何类型
+func (r *geometryReader) expectType(want uint32) error {
+	if len(r.buf) < 1 {
+		return errInvalidGeometry
+	}
+	switch r.buf[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return fmt.Errorf("%w: unknown byte order %d", errInvalidGeometry, r.buf[0])
+	}
+	r.buf = r.buf[1:]
+	typ, err := r.readUint32()
+	if err != nil {
+		return err
+	}
+	if typ != want {
+		return fmt.Errorf("%w: geometry type %d, expected %d", errInvalidGeometry, typ, want)
+	}
+	return nil
+}
+
+func (r *geometryReader) readUint32() (uint32, error) {
+	if len(r.buf) < 4 {
+		return 0, errInvalidGeometry
+	}
+	v := r.order.Uint32(r.buf)
+	r.buf = r.buf[4:]
+	return v, nil
+}
+
+// readCount 读取元素个数，个数超过剩余字节可容纳的数量（每个元素至少 size 字节）时返回错误
+// 避免按不可信的个数预分配内存
+func (r *geometryReader) readCount(size int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(size) > uint64(len(r.buf)) {
+		return 0, fmt.Errorf("%w: count %d exceeds remaining %d bytes", errInvalidGeometry, n, len(r.buf))
+	}
+	return int(n), nil
+}
+
+func (r *geometryReader) point() (Point, error) {
+	if len(r.buf) < 16 {
+		return Point{}, errInvalidGeometry
+	}
+	x := math.Float64frombits(r.order.Uint64(r.buf))
+	y := math.Float64frombits(r.order.Uint64(r.buf[8:]))
+	r.buf = r.buf[16:]
+	return Point{Lng: x, Lat: y}, nil
+}
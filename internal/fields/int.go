@@ -177,3 +177,8 @@ func (e IntExpr[T]) ToDateTime() DateTimeExpr[string] {
 func (e IntExpr[T]) Unwrap() clause.Expression {
 	return e.numericComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "Int"，用于 INSERT 目标列与插入数据的类型校验
+func (e IntExpr[T]) TypeFamily() string {
+	return "Int"
+}
@@ -422,3 +422,8 @@ func JsonObjectAgg(key, value clause.Expression) JsonExpr[string] {
 		Vars: []any{key, value},
 	})
 }
+
+// TypeFamily 返回类型族 "Json"，用于 INSERT 目标列与插入数据的类型校验
+func (e JsonExpr[T]) TypeFamily() string {
+	return "Json"
+}
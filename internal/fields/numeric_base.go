@@ -196,7 +196,7 @@ func (f baseComparableImpl[T]) NotInSubquery(subquery clause.Expression) Conditi
 }
 
 func (f baseComparableImpl[T]) operateValue(value any, operator string) Condition {
-	return Condition{clause.Expr{SQL: "? " + operator + " ?", Vars: []any{f.Expression, value}}}
+	return Condition{clause.Expr{SQL: "? " + operator + " ?", Vars: []any{f.Expression, clauses2.Bind(f.Expression, value)}}}
 }
 
 // ==================== 数值比较操作的通用实现 ====================
@@ -1331,7 +1331,7 @@ func (f patternExprImpl[T]) operateValue(value any, operator string, escape byte
 	var expr clause.Expression = clause.Like{
 		Column: f.Expression,
 		Value: clauses2.EscapeClause{
-			Value:  value,
+			Value:  clauses2.Bind(f.Expression, value),
 			Escape: escape,
 		},
 	}
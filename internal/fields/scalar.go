@@ -69,3 +69,8 @@ func (s ScalarExpr[T]) ToDate() DateExpr[T] {
 func (s ScalarExpr[T]) Unwrap() clause.Expression {
 	return s.baseComparableImpl.Expression
 }
+
+// TypeFamily 标量表达式的类型未知，返回空字符串，INSERT 类型校验时视为兼容
+func (s ScalarExpr[T]) TypeFamily() string {
+	return ""
+}
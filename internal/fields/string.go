@@ -2,8 +2,10 @@ package fields
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/clauses2"
 )
 
 var _ clause.Expression = (*StringExpr[string])(nil)
@@ -339,6 +341,56 @@ func (e StringExpr[T]) RPad(length int, padStr string) StringExpr[T] {
 	})
 }
 
+// ==================== 正则表达式 ====================
+
+// RegexpLike 正则匹配 (REGEXP_LIKE)，pattern 作为参数绑定
+// matchType 为可选的匹配标志：c 区分大小写，i 不区分大小写，m 多行模式，n 点号匹配换行，u 仅以 \n 作为换行符
+// matchType 包含其它字符时执行返回 ErrRegexpMatchType
+// 数据库支持: MySQL 8.0+, PostgreSQL（输出为 ~ / ~*，仅支持 c、i 标志）
+// SELECT * FROM users WHERE REGEXP_LIKE(email, '^[a-z]+@example\\.com$');
+// SELECT * FROM users WHERE REGEXP_LIKE(name, '^abc', 'i');
+func (e StringExpr[T]) RegexpLike(pattern string, matchType ...string) clause.Expression {
+	return e.regexp(clauses2.RegexpLike, pattern, "", matchType)
+}
+
+// NotRegexpLike 正则不匹配 (NOT REGEXP_LIKE)，参数同 RegexpLike
+// 数据库支持: MySQL 8.0+, PostgreSQL（输出为 !~ / !~*）
+// SELECT * FROM users WHERE NOT REGEXP_LIKE(phone, '^[0-9]+$');
+func (e StringExpr[T]) NotRegexpLike(pattern string, matchType ...string) clause.Expression {
+	return e.regexp(clauses2.RegexpNotLike, pattern, "", matchType)
+}
+
+// RegexpReplace 替换所有匹配正则的子串 (REGEXP_REPLACE)，pattern 和 replacement 作为参数绑定
+// 数据库支持: MySQL 8.0+, PostgreSQL（输出为 regexp_replace(..., 'g')）
+// SELECT REGEXP_REPLACE(content, '[0-9]{11}', '***') FROM users;
+func (e StringExpr[T]) RegexpReplace(pattern, replacement string, matchType ...string) StringExpr[T] {
+	return StringOf[T](e.regexp(clauses2.RegexpReplace, pattern, replacement, matchType))
+}
+
+// RegexpSubstr 返回第一个匹配正则的子串，无匹配时返回 NULL (REGEXP_SUBSTR)
+// 数据库支持: MySQL 8.0+, PostgreSQL 15+
+// SELECT REGEXP_SUBSTR(email, '@.*$') FROM users;
+func (e StringExpr[T]) RegexpSubstr(pattern string, matchType ...string) StringExpr[T] {
+	return StringOf[T](e.regexp(clauses2.RegexpSubstr, pattern, "", matchType))
+}
+
+// RegexpInstr 返回第一个匹配正则的子串的起始位置（从1开始），未找到返回0 (REGEXP_INSTR)
+// 数据库支持: MySQL 8.0+, PostgreSQL 15+
+// SELECT REGEXP_INSTR(email, '[0-9]') FROM users;
+func (e StringExpr[T]) RegexpInstr(pattern string, matchType ...string) IntExpr[int64] {
+	return IntOf[int64](e.regexp(clauses2.RegexpInstr, pattern, "", matchType))
+}
+
+func (e StringExpr[T]) regexp(fn clauses2.RegexpFunc, pattern, replacement string, matchType []string) clauses2.Regexp {
+	return clauses2.Regexp{
+		Func:        fn,
+		Expr:        e.Unwrap(),
+		Pattern:     pattern,
+		Replacement: replacement,
+		MatchType:   strings.Join(matchType, ""),
+	}
+}
+
 // ==================== 日期时间转换 ====================
 
 // ToDate 将字符串按照指定格式转换为日期/时间 (STR_TO_DATE)
@@ -368,3 +420,8 @@ func (e StringExpr[T]) InetAton() IntExpr[uint32] {
 func (e StringExpr[T]) Unwrap() clause.Expression {
 	return e.baseComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "String"，用于 INSERT 目标列与插入数据的类型校验
+func (e StringExpr[T]) TypeFamily() string {
+	return "String"
+}
@@ -87,3 +87,8 @@ func (e TimeExpr[T]) CastChar(length ...int) StringExpr[string] {
 func (e TimeExpr[T]) Unwrap() clause.Expression {
 	return e.numericComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "Time"，用于 INSERT 目标列与插入数据的类型校验
+func (e TimeExpr[T]) TypeFamily() string {
+	return "Time"
+}
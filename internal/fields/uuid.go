@@ -0,0 +1,262 @@
+package fields
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/clauses2"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	gormclause "gorm.io/gorm/clause"
+)
+
+// UUIDValue UUIDField 可以使用的取值类型，如 uuid.UUID、BinaryUUID
+type UUIDValue interface {
+	~[16]byte
+}
+
+// ==================== UUIDField ====================
+
+// UUIDField 以 BINARY(16) 保存的 UUID 字段，由 0gen.go 生成到 field_generate.go，这里是 UUID 专用的绑定方法
+// T 可以是 uuid.UUID、BinaryUUID 等 [16]byte 类型
+// 默认按 UUID_TO_BIN(?, 1) 交换时间位存储，未交换的列使用 WithoutSwap
+// 数据库支持: MySQL 8.0+
+// 示例:
+//
+//	ID: gsql.UUIDFieldOf[uuid.UUID]("users", "id")
+//	gsql.Select(u.ID.Text().As("id")).From(u).Where(u.ID.Eq(id)) // WHERE `users`.`id` = UUID_TO_BIN('...', 1)
+
+// TypeFamily 返回类型族 "UUID"，用于 INSERT 目标列与插入数据的类型校验
+func (f UUIDField[T]) TypeFamily() string {
+	return "UUID"
+}
+
+// WithoutSwap 返回按 UUID_TO_BIN(?, 0) 存储（不交换时间位）的字段
+// 结构体中对应的字段使用 BinaryUUIDNoSwap，BinaryUUID 按交换时间位的格式读写
+func (f UUIDField[T]) WithoutSwap() UUIDField[T] {
+	ret := f.WithAlias(f.Alias())
+	ret.noSwap = true
+	return ret
+}
+
+/////////////// uuid ///////////////
+
+// Eq 等于 (= UUID_TO_BIN(?, swap))
+// SELECT * FROM users WHERE id = UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1);
+func (f UUIDField[T]) Eq(value T) Condition {
+	return Condition{clause.Expr{SQL: "? = ?", Vars: []any{f.unaliased(), clauses2.Bind(f.column, f.toBin(value))}}}
+}
+
+// Not 不等于 (!= UUID_TO_BIN(?, swap))
+func (f UUIDField[T]) Not(value T) Condition {
+	return Condition{clause.Expr{SQL: "? != ?", Vars: []any{f.unaliased(), clauses2.Bind(f.column, f.toBin(value))}}}
+}
+
+// In 在取值列表中 (IN)，values 为空时返回空条件
+// SELECT * FROM users WHERE id IN (UUID_TO_BIN('...', 1), UUID_TO_BIN('...', 1));
+func (f UUIDField[T]) In(values ...T) Condition {
+	return f.in("IN", values)
+}
+
+// NotIn 不在取值列表中 (NOT IN)，values 为空时返回空条件
+func (f UUIDField[T]) NotIn(values ...T) Condition {
+	return f.in("NOT IN", values)
+}
+
+func (f UUIDField[T]) in(op string, values []T) Condition {
+	if len(values) == 0 {
+		return emptyCondition
+	}
+	return Condition{clause.Expr{
+		SQL: "? " + op + " ?",
+		Vars: []any{f.unaliased(), clauses2.Bind(f.column, lo.Map(values, func(v T, _ int) any {
+			return f.toBin(v)
+		}))},
+	}}
+}
+
+// Set 赋值 column = UUID_TO_BIN(?, swap)，用于 UPDATE 和 ON DUPLICATE KEY UPDATE
+func (f UUIDField[T]) Set(value T) Assignment {
+	return Assignment{Column: f, Value: f.toBin(value)}
+}
+
+// SetNew 赋值为数据库生成的新 UUID (UUID_TO_BIN(UUID(), swap))，等同于 gsql.Set(f, gsql.Sys.UUIDBin())
+// UPDATE users SET token = UUID_TO_BIN(UUID(), 1) WHERE id = ...;
+func (f UUIDField[T]) SetNew() Assignment {
+	return Assignment{Column: f, Value: UUIDToBin(clause.Expr{SQL: "UUID()"}, !f.noSwap)}
+}
+
+// Text 转换为 36 字符的 UUID 文本 (BIN_TO_UUID)，用于扫描到 string
+// SELECT BIN_TO_UUID(id, 1) AS id FROM users;
+// 示例: gsql.Select(u.ID.Text().As("id")).From(u)
+func (f UUIDField[T]) Text() StringExpr[string] {
+	return StringOf[string](clause.Expr{SQL: "BIN_TO_UUID(?, ?)", Vars: []any{f.unaliased(), f.swapFlag()}})
+}
+
+func (f UUIDField[T]) toBin(value T) clause.Expression {
+	return UUIDToBin(clause.Expr{SQL: "?", Vars: []any{formatUUID(value)}}, !f.noSwap)
+}
+
+func (f UUIDField[T]) swapFlag() int {
+	return lo.Ternary(f.noSwap, 0, 1)
+}
+
+// unaliased 返回不带 AS 别名的列，用于函数参数和比较
+func (f UUIDField[T]) unaliased() *clauses2.ColumnQuote {
+	return &clauses2.ColumnQuote{TableName: f.column.TableName, ColumnName: f.column.ColumnName, RowAlias: f.column.RowAlias}
+}
+
+// UUIDToBin 将 UUID 文本转换为 BINARY(16) (UUID_TO_BIN)，swap 为 true 时交换时间位
+// SELECT UUID_TO_BIN(UUID(), 1);
+// 数据库支持: MySQL 8.0+
+func UUIDToBin(expr clause.Expression, swap bool) ScalarExpr[[]byte] {
+	return ScalarOf[[]byte](clause.Expr{SQL: "UUID_TO_BIN(?, ?)", Vars: []any{expr, lo.Ternary(swap, 1, 0)}})
+}
+
+// ==================== BinaryUUID ====================
+
+// BinaryUUID 按 UUID_TO_BIN(?, 1) 存储的 UUID，可直接作为 BINARY(16) 列的字段类型读写
+// 可以与 uuid.UUID 直接互相转换: gsql.BinaryUUID(uuid.New())、uuid.UUID(row.ID)
+// 需要由数据库生成时，使用列默认值 default:(UUID_TO_BIN(UUID(), 1)) 或 gsql.Sys.UUIDBin()
+// 按 UUID_TO_BIN(?, 0) 存储的列（UUIDField.WithoutSwap）使用 BinaryUUIDNoSwap
+// 示例:
+//
+//	type User struct {
+//		ID   gsql.BinaryUUID `gorm:"primaryKey"`
+//		Name string
+//	}
+type BinaryUUID [16]byte
+
+var _ driver.Valuer = BinaryUUID{}
+
+// String 返回 36 字符的 UUID 文本
+func (u BinaryUUID) String() string {
+	return formatUUID(u)
+}
+
+func (u BinaryUUID) GormValue(_ context.Context, _ *gorm.DB) gormclause.Expr {
+	return clause.Expr{SQL: "UUID_TO_BIN(?, 1)", Vars: []any{u.String()}}.Compat()
+}
+
+func (BinaryUUID) GormDataType() string {
+	return "binary(16)"
+}
+
+// Value 返回交换时间位后的 16 字节，用于不经过 GormValue 的参数绑定
+func (u BinaryUUID) Value() (driver.Value, error) {
+	var b [16]byte
+	copy(b[0:2], u[6:8])
+	copy(b[2:4], u[4:6])
+	copy(b[4:8], u[0:4])
+	copy(b[8:], u[8:])
+	return b[:], nil
+}
+
+// Scan 读取交换时间位的 16 字节，或 BIN_TO_UUID 返回的 36 字符文本
+func (u *BinaryUUID) Scan(src any) error {
+	b, err := scanUUIDBytes(src, (*[16]byte)(u), "BinaryUUID")
+	if err != nil || b == nil {
+		return err
+	}
+	copy(u[0:4], b[4:8])
+	copy(u[4:6], b[2:4])
+	copy(u[6:8], b[0:2])
+	copy(u[8:], b[8:])
+	return nil
+}
+
+// ==================== BinaryUUIDNoSwap ====================
+
+// BinaryUUIDNoSwap 按 UUID_TO_BIN(?, 0) 存储（不交换时间位）的 UUID，与 UUIDField.WithoutSwap 对应
+// 列的存储格式必须与读写使用的类型一致，交换时间位的列使用 BinaryUUID
+// 示例:
+//
+//	type Session struct {
+//		ID gsql.BinaryUUIDNoSwap `gorm:"primaryKey"`
+//	}
+//	ID: gsql.UUIDFieldOf[gsql.BinaryUUIDNoSwap]("sessions", "id").WithoutSwap()
+type BinaryUUIDNoSwap [16]byte
+
+var _ driver.Valuer = BinaryUUIDNoSwap{}
+
+// String 返回 36 字符的 UUID 文本
+func (u BinaryUUIDNoSwap) String() string {
+	return formatUUID(u)
+}
+
+func (u BinaryUUIDNoSwap) GormValue(_ context.Context, _ *gorm.DB) gormclause.Expr {
+	return clause.Expr{SQL: "UUID_TO_BIN(?, 0)", Vars: []any{u.String()}}.Compat()
+}
+
+func (BinaryUUIDNoSwap) GormDataType() string {
+	return "binary(16)"
+}
+
+// Value 返回原始的 16 字节，用于不经过 GormValue 的参数绑定
+func (u BinaryUUIDNoSwap) Value() (driver.Value, error) {
+	return u[:], nil
+}
+
+// Scan 读取未交换时间位的 16 字节，或 BIN_TO_UUID 返回的 36 字符文本
+func (u *BinaryUUIDNoSwap) Scan(src any) error {
+	b, err := scanUUIDBytes(src, (*[16]byte)(u), "BinaryUUIDNoSwap")
+	if err != nil || b == nil {
+		return err
+	}
+	copy(u[:], b)
+	return nil
+}
+
+// scanUUIDBytes 处理 NULL 和 36 字符文本，返回需要由调用方按存储格式转换的 16 字节
+// 返回 nil 时 dst 已经写入
+func scanUUIDBytes(src any, dst *[16]byte, typeName string) ([]byte, error) {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*dst = [16]byte{}
+		return nil, nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return nil, fmt.Errorf("cannot scan %T into %s", src, typeName)
+	}
+	switch len(b) {
+	case 16:
+		return b, nil
+	case 36:
+		return nil, parseUUID(string(b), dst)
+	default:
+		return nil, fmt.Errorf("invalid uuid length %d", len(b))
+	}
+}
+
+func formatUUID[T UUIDValue](u T) string {
+	b := [16]byte(u)
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf[:])
+}
+
+func parseUUID(s string, dst *[16]byte) error {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return fmt.Errorf("invalid uuid %q", s)
+	}
+	hexStr := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(dst[:], []byte(hexStr)); err != nil {
+		return fmt.Errorf("invalid uuid %q: %w", s, err)
+	}
+	return nil
+}
@@ -74,3 +74,8 @@ func (e YearExpr[T]) CastChar(length ...int) StringExpr[string] {
 func (e YearExpr[T]) Unwrap() clause.Expression {
 	return e.numericComparableImpl.Expression
 }
+
+// TypeFamily 返回类型族 "Year"，用于 INSERT 目标列与插入数据的类型校验
+func (e YearExpr[T]) TypeFamily() string {
+	return "Year"
+}
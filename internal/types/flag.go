@@ -9,4 +9,5 @@ const (
 	FlagUniqueIndex   FieldFlag = 1 << 1 // 唯一索引
 	FlagIndex         FieldFlag = 1 << 2 // 普通索引
 	FlagAutoIncrement FieldFlag = 1 << 3 // 自增
+	FlagSensitive     FieldFlag = 1 << 4 // 敏感数据，日志中的参数会被脱敏
 )
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
@@ -127,6 +128,15 @@ func (l *sqlLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 	}
 }
 
+// explainSQL 按 gorm 回调的方式生成日志中的 SQL：logger 实现 ParamsFilter 时先过滤参数
+// 需要在 Trace 的 fc 中调用
+func explainSQL(ctx context.Context, l logger.Interface, dialector gorm.Dialector, sql string, vars []any) string {
+	if filter, ok := l.(gorm.ParamsFilter); ok {
+		sql, vars = filter.ParamsFilter(ctx, sql, vars...)
+	}
+	return dialector.Explain(sql, vars...)
+}
+
 // FileWithLineNum return the file name and line number of the current file
 func FileWithLineNum() string {
 	pcs := [13]uintptr{}
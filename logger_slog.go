@@ -0,0 +1,140 @@
+package gsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SlogConfig NewSlogLogger 的配置
+type SlogConfig struct {
+	SlowThreshold             time.Duration
+	LogLevel                  logger.LogLevel
+	IgnoreRecordNotFoundError bool
+	// Redactor 参数脱敏规则，为 nil 时输出原始参数
+	Redactor *Redactor
+}
+
+type slogLogger struct {
+	*slog.Logger
+	SlogConfig
+}
+
+var _ gorm.ParamsFilter = (*slogLogger)(nil)
+
+// NewSlogLogger 创建基于 log/slog 的 logger，SQL 以占位符形式输出，参数单独输出并按 Redactor 脱敏
+// 输出的属性: sql、fingerprint、args、duration、rows、caller、error
+// fingerprint 为构建语句时计算的指纹，与构建器的 Fingerprint() 相同
+// 直接使用 gorm 执行的语句没有参数对应的列，sql 中的参数按 Redactor 全部脱敏后内联输出，不输出 args 和 fingerprint
+// 示例:
+//
+//	db.Logger = gsql.NewSlogLogger(slog.Default(), gsql.SlogConfig{
+//		SlowThreshold: 200 * time.Millisecond,
+//		LogLevel:      logger.Warn,
+//		Redactor:      gsql.NewRedactor().Sensitive(u).Column("token"),
+//	})
+func NewSlogLogger(l *slog.Logger, cfg SlogConfig) logger.Interface {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &slogLogger{Logger: l, SlogConfig: cfg}
+}
+
+func (l *slogLogger) LogMode(level logger.LogLevel) logger.Interface {
+	newLogger := *l
+	newLogger.LogLevel = level
+	return &newLogger
+}
+
+func (l *slogLogger) Info(ctx context.Context, msg string, data ...any) {
+	if l.LogLevel >= logger.Info {
+		l.InfoContext(ctx, fmt.Sprintf(msg, data...), slog.String("caller", FileWithLineNum()))
+	}
+}
+
+func (l *slogLogger) Warn(ctx context.Context, msg string, data ...any) {
+	if l.LogLevel >= logger.Warn {
+		l.WarnContext(ctx, fmt.Sprintf(msg, data...), slog.String("caller", FileWithLineNum()))
+	}
+}
+
+func (l *slogLogger) Error(ctx context.Context, msg string, data ...any) {
+	if l.LogLevel >= logger.Error {
+		l.ErrorContext(ctx, fmt.Sprintf(msg, data...), slog.String("caller", FileWithLineNum()))
+	}
+}
+
+func (l *slogLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	call := boundCallFrom(ctx)
+	if call != nil {
+		// 本条语句已执行完毕，同一个 Statement 再次构建时重新记录
+		defer call.bound.Reset()
+	}
+	if l.LogLevel <= logger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	var (
+		level slog.Level
+		msg   string
+		attrs []slog.Attr
+	)
+	switch {
+	case err != nil && l.LogLevel >= logger.Error && (!errors.Is(err, logger.ErrRecordNotFound) || !l.IgnoreRecordNotFoundError):
+		level, msg = slog.LevelError, "sql error"
+	case elapsed > l.SlowThreshold && l.SlowThreshold != 0 && l.LogLevel >= logger.Warn:
+		level, msg = slog.LevelWarn, "slow sql"
+		attrs = append(attrs, slog.Duration("slow_threshold", l.SlowThreshold))
+	case l.LogLevel == logger.Info:
+		level, msg = slog.LevelInfo, "sql"
+	default:
+		return
+	}
+
+	sql, vars, fingerprint, rows := call.capture(fc)
+	attrs = append(attrs, slog.String("sql", sql))
+	if fingerprint != "" {
+		attrs = append(attrs, slog.String("fingerprint", fingerprint))
+	}
+	attrs = append(attrs,
+		slog.Any("args", vars),
+		slog.Duration("duration", elapsed),
+		slog.Int64("rows", rows),
+		slog.String("caller", FileWithLineNum()),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// capture 调用 fc，返回其中 ParamsFilter 记录的占位符 SQL、参数和语句构建时计算的指纹
+// 不是通过 gsql 执行的语句（c 为 nil）或 ParamsFilter 收到的不是记录的语句时返回 fc 的结果
+func (c *boundCall) capture(fc func() (string, int64)) (string, []any, string, int64) {
+	if c == nil {
+		sql, rows := fc()
+		return sql, nil, "", rows
+	}
+	c.ok = false
+	sql, rows := fc()
+	if !c.ok {
+		return sql, nil, "", rows
+	}
+	return c.sql, c.vars, fingerprintOf(c.bound.Stmt), rows
+}
+
+func (l *slogLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	call := boundCallFrom(ctx)
+	columns, ok := call.columns(sql, params)
+	params = l.Redactor.Redact(columns, params)
+	if ok {
+		call.sql, call.vars, call.ok = sql, params, true
+	}
+	return sql, params
+}
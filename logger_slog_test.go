@@ -0,0 +1,202 @@
+package gsql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/field"
+	"gorm.io/gorm/logger"
+)
+
+type account struct {
+	ID    int64  `gorm:"column:id;primaryKey"`
+	Email string `gorm:"column:email"`
+	Name  string `gorm:"column:name"`
+}
+
+func (account) TableName() string { return "accounts" }
+
+type accountTable struct {
+	ID    gsql.IntField[int64]
+	Email gsql.StringField[string]
+	Name  gsql.StringField[string]
+}
+
+func (accountTable) TableName() string  { return "accounts" }
+func (accountTable) ModelType() account { return account{} }
+
+var accounts = accountTable{
+	ID:    gsql.IntFieldOf[int64]("accounts", "id", field.FlagPrimaryKey),
+	Email: gsql.StringFieldOf[string]("accounts", "email", field.FlagSensitive),
+	Name:  gsql.StringFieldOf[string]("accounts", "name"),
+}
+
+func TestRedactor(t *testing.T) {
+	r := gsql.NewRedactor().Sensitive(accounts).Column("token", func(v any) any { return "t***" })
+	const x = gsql.RedactedValue
+
+	tests := []struct {
+		columns []string
+		vars    []any
+		expect  []any
+	}{
+		{
+			[]string{"email", "id", "id", ""},
+			[]any{"a@b.c", 1, 2, 10},
+			[]any{x, 1, 2, x},
+		},
+		{
+			[]string{"name", "EMAIL", "token"},
+			[]any{"n1", "e1", "t1"},
+			[]any{"n1", x, "t***"},
+		},
+		// 超出 columns 长度的参数同样视为无法确定列
+		{
+			[]string{"name"},
+			[]any{"n1", "n2"},
+			[]any{"n1", x},
+		},
+		{
+			nil,
+			[]any{"a", 1},
+			[]any{x, x},
+		},
+	}
+	for _, tt := range tests {
+		vars := append([]any(nil), tt.vars...)
+		if got := r.Redact(tt.columns, vars); !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%v\n期望 %v，实际: %v", tt.columns, tt.expect, got)
+		}
+		if !reflect.DeepEqual(vars, tt.vars) {
+			t.Errorf("Redact 不应修改传入的参数: %v", vars)
+		}
+	}
+
+	// Unmatched 修改无法确定列的参数的脱敏方式
+	r = gsql.NewRedactor().Sensitive(accounts).Unmatched(func(v any) any { return "?" })
+	if got := r.Redact([]string{"email", ""}, []any{"a@b.c", 10}); !reflect.DeepEqual(got, []any{x, "?"}) {
+		t.Errorf("Unmatched: 期望 [%s ?]，实际: %v", x, got)
+	}
+	// 没有配置时原样返回
+	var empty *gsql.Redactor
+	if got := empty.Redact(nil, []any{"a"}); !reflect.DeepEqual(got, []any{"a"}) {
+		t.Errorf("nil Redactor: %v", got)
+	}
+}
+
+// 参数对应的列在构建语句时记录，不依赖 SQL 文本
+func TestSlogLogger_BoundColumns(t *testing.T) {
+	const x = gsql.RedactedValue
+	db, _ := openCaptureDB(t, "8.0.36")
+	var buf bytes.Buffer
+	db.Logger = gsql.NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)), gsql.SlogConfig{
+		LogLevel: logger.Info,
+		Redactor: gsql.NewRedactor().Sensitive(accounts),
+	})
+	other := gsql.StringFieldOf[string]("profiles", "email")
+
+	run := []func(){
+		func() {
+			_, _ = gsql.SelectG[account]().From(accounts).
+				Where(gsql.Expr("? = ?", "a@b.c", accounts.Email), accounts.ID.In(1, 2), accounts.Name.Like("bob%")).
+				Limit(10).Find(db)
+		},
+		func() {
+			// 列名相同的其他表同样按列名脱敏
+			_, _ = gsql.SelectG[account]().From(accounts).Where(other.Eq("c@d.e"), accounts.Name.Eq("bob")).Find(db)
+		},
+		func() {
+			_ = gsql.Select().From(accounts).Where(accounts.ID.Eq(1)).
+				Update(db, []gsql.Assignment{
+					gsql.Set(accounts.Name, gsql.IF(accounts.Email.Eq("a@b.c"), gsql.StringVal("bob"), accounts.Name.Expr())),
+				})
+		},
+	}
+	expects := [][]any{
+		{x, float64(1), float64(2), "bob%", x},
+		{x, "bob"},
+		{x, "bob", float64(1)},
+	}
+	for i, fn := range run {
+		buf.Reset()
+		fn()
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("#%d unmarshal: %v %s", i, err, buf.String())
+		}
+		if args, _ := entry["args"].([]any); !reflect.DeepEqual(args, expects[i]) {
+			t.Errorf("#%d 期望 %v，实际: %v\n%s", i, expects[i], args, entry["sql"])
+		}
+	}
+
+	// 直接使用 gorm 执行的语句没有参数对应的列，全部脱敏
+	buf.Reset()
+	db.Exec("UPDATE `accounts` SET `name` = ? WHERE `id` = ?", "secret", 1)
+	if strings.Contains(buf.String(), "secret") || !strings.Contains(buf.String(), x) {
+		t.Errorf("gorm 执行的语句应全部脱敏: %s", buf.String())
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	db, _ := openCaptureDB(t, "8.0.36")
+	db.Logger = gsql.NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)), gsql.SlogConfig{
+		LogLevel: logger.Info,
+		Redactor: gsql.NewRedactor().Sensitive(accounts),
+	})
+	row := account{ID: 1, Email: "secret@example.com", Name: "bob"}
+
+	insert := gsql.InsertInto(accounts).Value(row)
+	upsert := gsql.InsertInto(accounts).Value(row).DuplicateUpdate(accounts.Email)
+	query := gsql.SelectG[account]().From(accounts).Where(accounts.Email.Eq("secret@example.com"))
+	_ = insert.Exec(db)
+	_ = upsert.Exec(db)
+	_ = gsql.Select().From(accounts).Where(accounts.ID.Eq(1)).Update(db, gsql.Set(accounts.Email, gsql.StringVal("secret@example.com")))
+	_, _ = query.Find(db)
+	fingerprints := []string{insert.Fingerprint(), upsert.Fingerprint(), "", query.Fingerprint()}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("期望 4 条日志，实际: %s", buf.String())
+	}
+	for i, line := range lines {
+		if strings.Contains(line, "secret@example.com") {
+			t.Errorf("日志中不应包含敏感参数: %s", line)
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		sql, _ := entry["sql"].(string)
+		args, _ := entry["args"].([]any)
+		if !strings.Contains(sql, "?") || !slicesContains(args, gsql.RedactedValue) {
+			t.Errorf("sql 应使用占位符，args 应包含脱敏值: %s", line)
+		}
+		if entry["caller"] == "" || entry["duration"] == nil || entry["rows"] == nil || entry["fingerprint"] == "" {
+			t.Errorf("缺少 caller/duration/rows/fingerprint: %s", line)
+		}
+		if fingerprints[i] != "" && entry["fingerprint"] != fingerprints[i] {
+			t.Errorf("#%d fingerprint 应与构建器的指纹相同:\n%v\n%s", i, entry["fingerprint"], fingerprints[i])
+		}
+	}
+	if !strings.Contains(lines[3], `"level":"ERROR"`) || !strings.Contains(lines[3], `"error":`) {
+		t.Errorf("查询失败时应输出 error 级别日志: %s", lines[3])
+	}
+	if !strings.Contains(lines[1], "bob") {
+		t.Errorf("非敏感参数应原样输出: %s", lines[1])
+	}
+}
+
+func slicesContains(s []any, v any) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,312 @@
+package gsql
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// ErrorClass 执行错误的分类，用作指标标签，没有错误时为空字符串
+type ErrorClass string
+
+const (
+	ErrClassNone      ErrorClass = ""
+	ErrClassNotFound  ErrorClass = "not_found" // gorm.ErrRecordNotFound
+	ErrClassDuplicate ErrorClass = "duplicate" // 唯一键冲突
+	ErrClassDeadlock  ErrorClass = "deadlock"  // 死锁或锁等待超时
+	ErrClassTimeout   ErrorClass = "timeout"   // 执行超时，包括 context.DeadlineExceeded
+	ErrClassCanceled  ErrorClass = "canceled"  // context.Canceled
+	ErrClassOther     ErrorClass = "other"
+)
+
+const (
+	errCodeDuplicateEntry uint16 = 1062    // ER_DUP_ENTRY
+	pgUniqueViolation            = "23505" // PostgreSQL unique_violation
+)
+
+// ClassifyError 返回错误的分类
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrClassNone
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrClassNotFound
+	}
+	if IsRetryableError(err) {
+		return ErrClassDeadlock
+	}
+	if isQueryTimeout(err) {
+		return ErrClassTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrClassCanceled
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrClassDuplicate
+	}
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) && myErr.Number == errCodeDuplicateEntry {
+		return ErrClassDuplicate
+	}
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) && pgErr.SQLState() == pgUniqueViolation {
+		return ErrClassDuplicate
+	}
+	return ErrClassOther
+}
+
+// MetricsSample 一次执行的指标数据
+type MetricsSample struct {
+	Operation   Operation
+	Table       string
+	Fingerprint string // 见 ExecInfo.Fingerprint
+	Duration    time.Duration
+	Rows        int64
+	ErrorClass  ErrorClass
+}
+
+// MetricsCollector 接收每次执行的指标数据，需要支持并发调用
+type MetricsCollector interface {
+	Observe(ctx context.Context, sample MetricsSample)
+}
+
+// MetricsCollectorFunc 函数形式的 MetricsCollector
+type MetricsCollectorFunc func(ctx context.Context, sample MetricsSample)
+
+func (f MetricsCollectorFunc) Observe(ctx context.Context, sample MetricsSample) {
+	f(ctx, sample)
+}
+
+// Metrics 将 gsql 的每次执行（查询、插入、更新、删除）上报给 MetricsCollector 的插件
+// 通过 db.Use 注册，基于 ExecHook 实现
+// 示例:
+//
+//	mem := gsql.NewMemoryMetrics()
+//	db.Use(gsql.NewMetrics(mem, gsql.PrometheusCollector{Duration: ..., Errors: ...}))
+//	mem.Stats(gsql.OpSelect, "users").Count
+type Metrics struct {
+	collectors []MetricsCollector
+}
+
+var _ ExecHook = (*Metrics)(nil)
+
+func NewMetrics(collectors ...MetricsCollector) *Metrics {
+	return &Metrics{collectors: collectors}
+}
+
+func (m *Metrics) Name() string {
+	return "gsql:metrics"
+}
+
+func (m *Metrics) Initialize(*gorm.DB) error {
+	return nil
+}
+
+func (m *Metrics) BeforeExec(ctx context.Context, _ *ExecInfo) context.Context {
+	return ctx
+}
+
+func (m *Metrics) AfterExec(ctx context.Context, info *ExecInfo) {
+	sample := MetricsSample{
+		Operation:   info.Operation,
+		Table:       info.Table,
+		Fingerprint: info.Fingerprint,
+		Duration:    info.Duration,
+		Rows:        info.RowsAffected,
+		ErrorClass:  ClassifyError(info.Err),
+	}
+	for _, c := range m.collectors {
+		c.Observe(ctx, sample)
+	}
+}
+
+// DefaultMetricsBuckets NewMemoryMetrics 默认的耗时分桶上限
+var DefaultMetricsBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 500 * time.Millisecond, time.Second, 5 * time.Second,
+}
+
+// MetricsKey MemoryMetrics 的聚合维度
+type MetricsKey struct {
+	Operation Operation
+	Table     string
+}
+
+// MetricsStats 同一 MetricsKey 的聚合结果
+type MetricsStats struct {
+	Count    int64
+	Rows     int64
+	Total    time.Duration
+	Min, Max time.Duration
+	// Histogram[i] 为耗时不超过 Buckets[i] 且超过 Buckets[i-1] 的次数，最后一个元素为超过所有分桶的次数
+	Histogram    []int64
+	Errors       map[ErrorClass]int64
+	Fingerprints map[string]int64
+}
+
+// Mean 平均耗时
+func (s MetricsStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// ErrorCount 所有分类的错误次数之和
+func (s MetricsStats) ErrorCount() int64 {
+	var n int64
+	for _, v := range s.Errors {
+		n += v
+	}
+	return n
+}
+
+// MemoryMetrics 在内存中按操作类型和表聚合的 MetricsCollector，可用于测试断言或调试输出
+type MemoryMetrics struct {
+	mu      sync.Mutex
+	buckets []time.Duration
+	stats   map[MetricsKey]*MetricsStats
+}
+
+var _ MetricsCollector = (*MemoryMetrics)(nil)
+
+// NewMemoryMetrics buckets 为升序的耗时分桶上限，省略时使用 DefaultMetricsBuckets
+func NewMemoryMetrics(buckets ...time.Duration) *MemoryMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultMetricsBuckets
+	}
+	return &MemoryMetrics{
+		buckets: slices.Clone(buckets),
+		stats:   make(map[MetricsKey]*MetricsStats),
+	}
+}
+
+func (m *MemoryMetrics) Observe(_ context.Context, sample MetricsSample) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := MetricsKey{Operation: sample.Operation, Table: sample.Table}
+	s, ok := m.stats[key]
+	if !ok {
+		s = &MetricsStats{
+			Min:          sample.Duration,
+			Histogram:    make([]int64, len(m.buckets)+1),
+			Errors:       make(map[ErrorClass]int64),
+			Fingerprints: make(map[string]int64),
+		}
+		m.stats[key] = s
+	}
+	s.Count++
+	s.Rows += sample.Rows
+	s.Total += sample.Duration
+	s.Min = min(s.Min, sample.Duration)
+	s.Max = max(s.Max, sample.Duration)
+	idx, _ := slices.BinarySearch(m.buckets, sample.Duration)
+	s.Histogram[idx]++
+	if sample.ErrorClass != ErrClassNone {
+		s.Errors[sample.ErrorClass]++
+	}
+	if sample.Fingerprint != "" {
+		s.Fingerprints[sample.Fingerprint]++
+	}
+}
+
+// Buckets 耗时分桶上限
+func (m *MemoryMetrics) Buckets() []time.Duration {
+	return slices.Clone(m.buckets)
+}
+
+// Stats 返回指定操作类型和表的聚合结果副本，没有数据时返回零值
+func (m *MemoryMetrics) Stats(op Operation, table string) MetricsStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.stats[MetricsKey{Operation: op, Table: table}]; ok {
+		return s.clone()
+	}
+	return MetricsStats{}
+}
+
+// Snapshot 返回所有聚合结果的副本
+func (m *MemoryMetrics) Snapshot() map[MetricsKey]MetricsStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make(map[MetricsKey]MetricsStats, len(m.stats))
+	for k, s := range m.stats {
+		ret[k] = s.clone()
+	}
+	return ret
+}
+
+// Reset 清空聚合结果
+func (m *MemoryMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.stats)
+}
+
+func (s *MetricsStats) clone() MetricsStats {
+	ret := *s
+	ret.Histogram = slices.Clone(s.Histogram)
+	ret.Errors = maps.Clone(s.Errors)
+	ret.Fingerprints = maps.Clone(s.Fingerprints)
+	return ret
+}
+
+// MetricsVec 带标签的指标，标签值的顺序与 MetricsLabels / MetricsErrorLabels 一致
+// prometheus 的 HistogramVec、CounterVec 可通过 MetricsVecFunc 适配:
+//
+//	gsql.MetricsVecFunc(func(v float64, labels ...string) { histogram.WithLabelValues(labels...).Observe(v) })
+//	gsql.MetricsVecFunc(func(v float64, labels ...string) { counter.WithLabelValues(labels...).Add(v) })
+type MetricsVec interface {
+	Observe(value float64, labelValues ...string)
+}
+
+// MetricsVecFunc 函数形式的 MetricsVec
+type MetricsVecFunc func(value float64, labelValues ...string)
+
+func (f MetricsVecFunc) Observe(value float64, labelValues ...string) {
+	f(value, labelValues...)
+}
+
+var (
+	// MetricsLabels PrometheusCollector 中 Duration、Rows 的标签名
+	MetricsLabels = []string{"operation", "table"}
+	// MetricsErrorLabels PrometheusCollector 中 Errors 的标签名
+	MetricsErrorLabels = []string{"operation", "table", "error_class"}
+)
+
+// PrometheusCollector 将执行指标输出到 Prometheus 风格的带标签指标，为 nil 的指标不输出
+// 指纹的基数不可控，不作为标签输出
+// 示例:
+//
+//	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "gsql_duration_seconds"}, gsql.MetricsLabels)
+//	errs := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "gsql_errors_total"}, gsql.MetricsErrorLabels)
+//	db.Use(gsql.NewMetrics(gsql.PrometheusCollector{
+//		Duration: gsql.MetricsVecFunc(func(v float64, l ...string) { duration.WithLabelValues(l...).Observe(v) }),
+//		Errors:   gsql.MetricsVecFunc(func(v float64, l ...string) { errs.WithLabelValues(l...).Add(v) }),
+//	}))
+type PrometheusCollector struct {
+	Duration MetricsVec // 耗时，单位秒
+	Rows     MetricsVec // 返回或影响的行数
+	Errors   MetricsVec // 错误次数，每次错误输出 1
+}
+
+var _ MetricsCollector = PrometheusCollector{}
+
+func (c PrometheusCollector) Observe(_ context.Context, sample MetricsSample) {
+	op, table := string(sample.Operation), sample.Table
+	if c.Duration != nil {
+		c.Duration.Observe(sample.Duration.Seconds(), op, table)
+	}
+	if c.Rows != nil {
+		c.Rows.Observe(float64(sample.Rows), op, table)
+	}
+	if c.Errors != nil && sample.ErrorClass != ErrClassNone {
+		c.Errors.Observe(1, op, table, string(sample.ErrorClass))
+	}
+}
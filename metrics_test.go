@@ -0,0 +1,152 @@
+package gsql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestMetrics(t *testing.T) {
+	db, pool := openCaptureDB(t, "8.0.36")
+	mem := gsql.NewMemoryMetrics()
+	var prom []string
+	record := func(name string) gsql.MetricsVec {
+		return gsql.MetricsVecFunc(func(v float64, labels ...string) {
+			prom = append(prom, fmt.Sprintf("%s{%s}", name, strings.Join(labels, ",")))
+		})
+	}
+	if err := db.Use(gsql.NewMetrics(mem, gsql.PrometheusCollector{
+		Duration: record("duration"),
+		Errors:   record("errors"),
+	})); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	table := NewMessageConsumerProgressTable()
+	row := MessageConsumerProgress{ID: 1, ConsumerGroup: "g"}
+
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.In(1, 2, 3)).Find(db)
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.In(4)).Find(db)
+	_ = gsql.InsertInto(table).Value(row).Exec(db)
+	_ = gsql.Select().From(table).Where(table.ID.Eq(1)).Update(db, map[string]any{"generation_id": 2})
+	pool.err = &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	_ = gsql.InsertInto(table).Value(row).Exec(db)
+
+	sel := mem.Stats(gsql.OpSelect, "message_consumer_progress")
+	if sel.Count != 2 || sel.ErrorCount() != 2 || len(sel.Fingerprints) != 1 {
+		t.Errorf("select 聚合不正确: %+v", sel)
+	}
+	for fp := range sel.Fingerprints {
+		if !strings.Contains(fp, "IN (?)") {
+			t.Errorf("指纹应合并 IN 列表，实际: %s", fp)
+		}
+	}
+	ins := mem.Stats(gsql.OpInsert, "message_consumer_progress")
+	if ins.Count != 2 || ins.Rows != 1 || ins.Errors[gsql.ErrClassDuplicate] != 1 {
+		t.Errorf("insert 聚合不正确: %+v", ins)
+	}
+	upd := mem.Stats(gsql.OpUpdate, "message_consumer_progress")
+	if upd.Count != 1 || upd.Rows != 1 || upd.ErrorCount() != 0 {
+		t.Errorf("update 聚合不正确: %+v", upd)
+	}
+	var total int64
+	for _, n := range ins.Histogram {
+		total += n
+	}
+	if total != ins.Count || len(ins.Histogram) != len(mem.Buckets())+1 {
+		t.Errorf("直方图计数不正确: %v", ins.Histogram)
+	}
+	if len(mem.Snapshot()) != 3 {
+		t.Errorf("期望 3 个聚合维度，实际: %v", mem.Snapshot())
+	}
+
+	expect := []string{
+		"duration{select,message_consumer_progress}", "errors{select,message_consumer_progress,other}",
+		"duration{select,message_consumer_progress}", "errors{select,message_consumer_progress,other}",
+		"duration{insert,message_consumer_progress}",
+		"duration{update,message_consumer_progress}",
+		"duration{insert,message_consumer_progress}", "errors{insert,message_consumer_progress,duplicate}",
+	}
+	if strings.Join(prom, " ") != strings.Join(expect, " ") {
+		t.Errorf("prometheus 输出不正确:\n期望 %v\n实际 %v", expect, prom)
+	}
+
+	mem.Reset()
+	if mem.Stats(gsql.OpSelect, "message_consumer_progress").Count != 0 {
+		t.Error("Reset 后应清空聚合结果")
+	}
+}
+
+func TestMetricsEmptyFind(t *testing.T) {
+	m := gsqltest.New(t)
+	mem := gsql.NewMemoryMetrics()
+	if err := m.DB().Use(gsql.NewMetrics(mem)); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	table := NewMessageConsumerProgressTable()
+	m.ExpectQueryContains("SELECT").WillReturnRows(gsqltest.NewRows("id")).Times(3)
+
+	// 列表查询没有结果不是错误
+	if _, err := gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).Find(m); err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if err := gsql.Select(table.ID).From(table).Where(table.ID.Eq(1)).Find(m, &[]map[string]any{}); err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	sel := mem.Stats(gsql.OpSelect, "message_consumer_progress")
+	if sel.Count != 2 || sel.ErrorCount() != 0 {
+		t.Errorf("空的 Find 不应记录错误: %+v", sel)
+	}
+
+	// First 没有结果仍记录为 not_found
+	if _, err := gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).First(m); err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	sel = mem.Stats(gsql.OpSelect, "message_consumer_progress")
+	if sel.Count != 3 || sel.Errors[gsql.ErrClassNotFound] != 1 {
+		t.Errorf("First 没有结果应记录 not_found: %+v", sel)
+	}
+}
+
+func TestMemoryMetricsBuckets(t *testing.T) {
+	mem := gsql.NewMemoryMetrics(10*time.Millisecond, 100*time.Millisecond)
+	for _, d := range []time.Duration{time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond, time.Second} {
+		mem.Observe(context.Background(), gsql.MetricsSample{Operation: gsql.OpSelect, Table: "t", Duration: d})
+	}
+	s := mem.Stats(gsql.OpSelect, "t")
+	if fmt.Sprint(s.Histogram) != "[2 1 1]" || s.Min != time.Millisecond || s.Max != time.Second {
+		t.Errorf("分桶统计不正确: %+v", s)
+	}
+	if s.Mean() != (time.Millisecond+10*time.Millisecond+50*time.Millisecond+time.Second)/4 {
+		t.Errorf("平均耗时不正确: %s", s.Mean())
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err    error
+		expect gsql.ErrorClass
+	}{
+		{nil, gsql.ErrClassNone},
+		{gorm.ErrRecordNotFound, gsql.ErrClassNotFound},
+		{fmt.Errorf("wrap: %w", gorm.ErrDuplicatedKey), gsql.ErrClassDuplicate},
+		{&mysql.MySQLError{Number: 1062}, gsql.ErrClassDuplicate},
+		{&mysql.MySQLError{Number: 1213}, gsql.ErrClassDeadlock},
+		{&mysql.MySQLError{Number: 3024}, gsql.ErrClassTimeout},
+		{context.DeadlineExceeded, gsql.ErrClassTimeout},
+		{context.Canceled, gsql.ErrClassCanceled},
+		{errors.New("boom"), gsql.ErrClassOther},
+	}
+	for _, tt := range tests {
+		if got := gsql.ClassifyError(tt.err); got != tt.expect {
+			t.Errorf("%v 期望 %q，实际: %q", tt.err, tt.expect, got)
+		}
+	}
+}
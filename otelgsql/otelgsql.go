@@ -0,0 +1,164 @@
+// Package otelgsql 为 gsql 的每次执行创建 OpenTelemetry span
+//
+// 属性遵循数据库语义约定：db.system、db.statement（占位符形式，不包含参数值）、
+// db.operation、db.sql.table 以及 db.rows_affected
+//
+// otelgsql 是独立的 Go module，不引入 OpenTelemetry 的应用无需依赖它：
+//
+//	go get github.com/donutnomad/gsql/otelgsql
+//
+// 示例:
+//
+//	db.Use(otelgsql.New(otelgsql.WithTracerProvider(tp)))
+//	gsql.SelectG[User]().From(u).Where(u.ID.Eq(1)).WithContext(ctx).First(db)
+package otelgsql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/donutnomad/gsql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	// ScopeName 创建 tracer 使用的 instrumentation scope
+	ScopeName = "github.com/donutnomad/gsql/otelgsql"
+
+	// RowsAffectedKey 影响或返回的行数
+	RowsAffectedKey = attribute.Key("db.rows_affected")
+	// OperationKey gsql 的语句类型，区分 upsert 与 insert
+	OperationKey = attribute.Key("gsql.operation")
+)
+
+var (
+	_ gorm.Plugin   = (*Plugin)(nil)
+	_ gsql.ExecHook = (*Plugin)(nil)
+)
+
+// Plugin 实现 gsql.ExecHook 的 gorm 插件
+type Plugin struct {
+	tracer           trace.Tracer
+	attrs            []attribute.KeyValue
+	withoutStatement bool
+}
+
+// Option Plugin 的配置项
+type Option func(*config)
+
+type config struct {
+	provider         trace.TracerProvider
+	attrs            []attribute.KeyValue
+	withoutStatement bool
+}
+
+// WithTracerProvider 使用指定的 TracerProvider，默认为 otel.GetTracerProvider()
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// WithAttributes 为每个 span 添加额外的属性，如 db.name
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(c *config) {
+		c.attrs = append(c.attrs, attrs...)
+	}
+}
+
+// WithoutStatement 不记录 db.statement
+func WithoutStatement() Option {
+	return func(c *config) {
+		c.withoutStatement = true
+	}
+}
+
+func New(opts ...Option) *Plugin {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.provider == nil {
+		c.provider = otel.GetTracerProvider()
+	}
+	return &Plugin{
+		tracer:           c.provider.Tracer(ScopeName),
+		attrs:            c.attrs,
+		withoutStatement: c.withoutStatement,
+	}
+}
+
+func (p *Plugin) Name() string {
+	return "gsql:otel"
+}
+
+func (p *Plugin) Initialize(*gorm.DB) error {
+	return nil
+}
+
+func (p *Plugin) BeforeExec(ctx context.Context, info *gsql.ExecInfo) context.Context {
+	op := sqlOperation(info.Operation)
+	name := op
+	if info.Table != "" {
+		name += " " + info.Table
+	}
+	attrs := append([]attribute.KeyValue{
+		dbSystem(info.Dialect),
+		semconv.DBOperation(op),
+		OperationKey.String(string(info.Operation)),
+	}, p.attrs...)
+	ctx, _ = p.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(info.Begin),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+func (p *Plugin) AfterExec(ctx context.Context, info *gsql.ExecInfo) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	if info.Table != "" {
+		span.SetAttributes(semconv.DBSQLTable(info.Table))
+	}
+	if !p.withoutStatement && info.SQL != "" {
+		span.SetAttributes(semconv.DBStatement(info.SQL))
+	}
+	span.SetAttributes(RowsAffectedKey.Int64(info.RowsAffected))
+	if info.Err != nil && !errors.Is(info.Err, gorm.ErrRecordNotFound) {
+		span.RecordError(info.Err)
+		span.SetStatus(codes.Error, info.Err.Error())
+	}
+	span.End(trace.WithTimestamp(info.Begin.Add(info.Duration)))
+}
+
+// sqlOperation 返回 db.operation 使用的 SQL 关键字
+func sqlOperation(op gsql.Operation) string {
+	if op == gsql.OpUpsert {
+		return "INSERT"
+	}
+	return strings.ToUpper(string(op))
+}
+
+func dbSystem(dialect string) attribute.KeyValue {
+	switch dialect {
+	case "mysql":
+		return semconv.DBSystemMySQL
+	case "postgres":
+		return semconv.DBSystemPostgreSQL
+	case "sqlite":
+		return semconv.DBSystemSqlite
+	case "sqlserver":
+		return semconv.DBSystemMSSQL
+	default:
+		return semconv.DBSystemOtherSQL
+	}
+}
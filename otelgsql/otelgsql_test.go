@@ -0,0 +1,160 @@
+package otelgsql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/otelgsql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// fakePool 记录执行时的 context，不连接真实数据库
+type fakePool struct {
+	ctxs     []context.Context
+	queryErr error
+}
+
+func (p *fakePool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, nil
+}
+
+func (p *fakePool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	p.ctxs = append(p.ctxs, ctx)
+	return driverResult(2), nil
+}
+
+func (p *fakePool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	p.ctxs = append(p.ctxs, ctx)
+	return nil, p.queryErr
+}
+
+func (p *fakePool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return nil
+}
+
+type driverResult int64
+
+func (r driverResult) LastInsertId() (int64, error) { return 1, nil }
+func (r driverResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+type user struct {
+	ID    int64  `gorm:"column:id;primaryKey"`
+	Email string `gorm:"column:email"`
+}
+
+func (user) TableName() string { return "users" }
+
+type userTable struct {
+	ID    gsql.IntField[int64]
+	Email gsql.StringField[string]
+}
+
+func (userTable) TableName() string { return "users" }
+func (userTable) ModelType() user   { return user{} }
+
+var users = userTable{
+	ID:    gsql.IntFieldOf[int64]("users", "id"),
+	Email: gsql.StringFieldOf[string]("users", "email"),
+}
+
+func setup(t *testing.T) (*gorm.DB, *fakePool, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	pool := &fakePool{}
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      pool,
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := db.Use(otelgsql.New(otelgsql.WithTracerProvider(provider))); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+	return db, pool, exporter
+}
+
+func attrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	ret := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		ret[kv.Key] = kv.Value
+	}
+	return ret
+}
+
+func TestUpdateSpan(t *testing.T) {
+	db, pool, exporter := setup(t)
+
+	ret := gsql.Select().From(users).Where(users.Email.Eq("a@example.com")).Update(db, gsql.Set(users.ID, gsql.IntVal(1)))
+	if ret.Error != nil {
+		t.Fatalf("update: %v", ret.Error)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("期望 1 个 span，实际: %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "UPDATE users" || span.SpanKind != trace.SpanKindClient {
+		t.Errorf("span 名称或类型不正确: %s %s", span.Name, span.SpanKind)
+	}
+	got := attrs(span)
+	for key, expect := range map[attribute.Key]string{
+		"db.system":      "mysql",
+		"db.operation":   "UPDATE",
+		"db.sql.table":   "users",
+		"gsql.operation": "update",
+	} {
+		if got[key].AsString() != expect {
+			t.Errorf("%s 期望 %s，实际: %s", key, expect, got[key].AsString())
+		}
+	}
+	if stmt := got["db.statement"].AsString(); !strings.Contains(stmt, "`users`.`email` = ?") || strings.Contains(stmt, "a@example.com") {
+		t.Errorf("db.statement 应使用占位符，实际: %s", stmt)
+	}
+	if got[otelgsql.RowsAffectedKey].AsInt64() != 2 {
+		t.Errorf("db.rows_affected 期望 2，实际: %d", got[otelgsql.RowsAffectedKey].AsInt64())
+	}
+	if sc := trace.SpanContextFromContext(pool.ctxs[0]); sc.SpanID() != span.SpanContext.SpanID() {
+		t.Error("执行时的 context 应携带当前 span")
+	}
+}
+
+func TestInsertAndQuerySpans(t *testing.T) {
+	db, pool, exporter := setup(t)
+	pool.queryErr = errors.New("connection refused")
+
+	if err := gsql.InsertInto(users).Value(user{Email: "a@example.com"}).DuplicateUpdate(users.Email).Exec(db); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if _, err := gsql.SelectG[user]().From(users).Find(db); err == nil {
+		t.Fatal("期望查询返回错误")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("期望 2 个 span，实际: %d", len(spans))
+	}
+	insert, query := spans[0], spans[1]
+	if insert.Name != "INSERT users" || attrs(insert)["gsql.operation"].AsString() != "upsert" {
+		t.Errorf("插入 span 不正确: %s %v", insert.Name, insert.Attributes)
+	}
+	if !strings.Contains(attrs(insert)["db.statement"].AsString(), "ON DUPLICATE KEY UPDATE") {
+		t.Errorf("db.statement 不正确: %s", attrs(insert)["db.statement"].AsString())
+	}
+	if query.Name != "SELECT users" || query.Status.Code != codes.Error || len(query.Events) == 0 {
+		t.Errorf("查询失败时 span 应记录错误: %s %+v", query.Name, query.Status)
+	}
+}
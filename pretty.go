@@ -0,0 +1,356 @@
+package gsql
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/donutnomad/gsql/clause"
+	"gorm.io/gorm"
+	gormclause "gorm.io/gorm/clause"
+)
+
+// KeywordCase ToPrettySQL 输出关键字的大小写
+type KeywordCase int
+
+const (
+	KeywordUpper KeywordCase = iota // 大写，与 ToSQL 相同
+	KeywordLower                    // 小写
+)
+
+// PrettyOptions ToPrettySQL 的选项，零值为大写关键字、两个空格缩进、不限制行宽
+type PrettyOptions struct {
+	KeywordCase KeywordCase
+	// Indent 每层缩进使用的字符串，为空时使用两个空格
+	Indent string
+	// LineWidth 一行超过该宽度时在逗号、AND、OR 处折行，0 表示不折行
+	LineWidth int
+}
+
+// PrettySQL 按表达式树格式化 SQL：每个子句一行，子查询、CTE、UNION、CASE 和 JOIN 分行缩进
+// 参数与 ToSQL 一样内联到 SQL 中，用于日志
+// 示例:
+//
+//	gsql.PrettySQL(gsql.UnionAll(q1, q2).ToExpr(), gsql.PrettyOptions{})
+func PrettySQL(expr clause.Expression, opts PrettyOptions) string {
+	if expr == nil {
+		return ""
+	}
+	e := PrettyExpr(expr, opts)
+	return dialector.Explain(e.SQL, e.Vars...)
+}
+
+// PrettyExpr 与 PrettySQL 相同地格式化 SQL，但参数保留为占位符，返回的 Expr 包含格式化的 SQL 和参数，用于快照
+func PrettyExpr(expr clause.Expression, opts PrettyOptions) clause.Expr {
+	if expr == nil {
+		return clause.Expr{}
+	}
+	p := newPrettyBuilder(opts)
+	if e, ok := expr.(clause.Expr); !ok || e.Source == nil || !p.buildStatement(e.Source) {
+		expr.Build(p)
+	}
+	p.endWord()
+	return clause.Expr{SQL: p.SQL.String(), Vars: p.Vars}
+}
+
+// ToPrettySQL 返回格式化的 SQL，见 PrettySQL
+func (b *QueryBuilderG[T]) ToPrettySQL(opts PrettyOptions) string {
+	return PrettySQL(b.ToExpr(), opts)
+}
+
+// ToPrettySQL 返回格式化的 SQL，见 PrettySQL
+func (b *QueryBuilder) ToPrettySQL(opts PrettyOptions) string {
+	return b.as().ToPrettySQL(opts)
+}
+
+// ToPrettyExpr 按子句格式化 Exec 执行的插入语句，参数保留为占位符，见 PrettyExpr
+// 使用 MySQL 8.0 的语法生成，不会修改要插入的值，也不会调用模型的钩子方法
+func (b *insertBuilderWithValues[T]) ToPrettyExpr(opts PrettyOptions) (clause.Expr, error) {
+	if b.values == nil || len(*b.values) == 0 {
+		return clause.Expr{}, gorm.ErrEmptySlice
+	}
+	rows := slices.Clone(*b.values)
+	stmt, err := b.dryRun(&rows)
+	if err != nil {
+		return clause.Expr{}, err
+	}
+	return PrettyExpr(clause.Expr{Source: stmt}, opts), nil
+}
+
+// ToPrettyExpr 按子句格式化 Exec 执行的插入语句，见 PrettyExpr
+func (b *insertBuilderWithSelect[T]) ToPrettyExpr(opts PrettyOptions) (clause.Expr, error) {
+	tx, _, err := b.build(dryRunDB())
+	if err != nil {
+		return clause.Expr{}, err
+	}
+	return PrettyExpr(clause.Expr{Source: tx.Statement}, opts), nil
+}
+
+// ToPrettyExpr 按子句格式化 Exec 执行的语句，见 PrettyExpr
+func (r *replaceBuilderWithValues[T]) ToPrettyExpr(opts PrettyOptions) (clause.Expr, error) {
+	return r.b.ToPrettyExpr(opts)
+}
+
+// ToPrettyExpr 按子句格式化 Exec 执行的语句，见 PrettyExpr
+func (r *replaceBuilderWithSelect[T]) ToPrettyExpr(opts PrettyOptions) (clause.Expr, error) {
+	return r.b.ToPrettyExpr(opts)
+}
+
+// prettyKeywords KeywordLower 时转换为小写的关键字
+var prettyKeywords = map[string]bool{}
+
+func init() {
+	for _, kw := range strings.Fields(`
+		SELECT DISTINCT FROM WHERE GROUP BY HAVING ORDER ASC DESC LIMIT OFFSET FOR UPDATE SHARE NOWAIT SKIP LOCKED
+		LOCK IN MODE WITH RECURSIVE AS UNION ALL JOIN LEFT RIGHT INNER OUTER FULL CROSS NATURAL STRAIGHT_JOIN ON USING
+		AND OR NOT IS NULL LIKE ESCAPE BETWEEN EXISTS CASE WHEN THEN ELSE END INSERT INTO VALUES SET DELETE
+		DUPLICATE KEY OVER PARTITION ROWS RANGE WINDOW INTERVAL DIV MOD XOR REGEXP MATCH AGAINST TRUE FALSE`) {
+		prettyKeywords[kw] = true
+	}
+}
+
+// prettyBuilder 实现 clause.PrettyBuilder，输出到内部的 Statement
+// 子查询通过 clause.Expr.Source 记录的语句按子句重新输出，而不是解析 SQL 字符串
+type prettyBuilder struct {
+	*Statement
+	opts PrettyOptions
+	cur  *Statement // 正在输出的语句，用于引用当前表
+
+	indent  int
+	newline bool // 下一次写入前换行
+	wrap    bool // 当前行因超过行宽折行，续行多缩进一层
+	spaces  int  // 尚未写入的空格，换行时丢弃
+	col     int
+	quote   byte   // 正在输出的字符串或标识符的引号
+	escape  bool   // 字符串中的下一个字符被反斜杠转义
+	word    []byte // 引号外尚未输出的单词，结束时转换关键字大小写
+}
+
+func newPrettyBuilder(opts PrettyOptions) *prettyBuilder {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+	stmt := newExprDB().Statement
+	return &prettyBuilder{Statement: stmt, opts: opts, cur: stmt}
+}
+
+// GormStatement 供需要读取 Statement 的表达式使用，见 clauses2.StatementOf
+func (p *prettyBuilder) GormStatement() *Statement {
+	return p.cur
+}
+
+func (p *prettyBuilder) Newline() {
+	p.endWord()
+	if p.SQL.Len() > 0 {
+		p.newline = true
+	}
+	p.wrap = false
+}
+
+func (p *prettyBuilder) Indent() {
+	p.endWord()
+	p.indent++
+}
+
+func (p *prettyBuilder) Dedent() {
+	p.endWord()
+	if p.indent > 0 {
+		p.indent--
+	}
+}
+
+// BuildSource 将子查询输出为缩进的块，外层的括号由调用方输出
+func (p *prettyBuilder) BuildSource(source any) bool {
+	if _, ok := source.(*Statement); !ok {
+		return false
+	}
+	p.Indent()
+	p.Newline()
+	p.buildStatement(source)
+	p.Dedent()
+	p.Newline()
+	return true
+}
+
+// buildStatement 按 BuildClauses 的顺序输出语句的子句，每个子句一行
+func (p *prettyBuilder) buildStatement(source any) bool {
+	stmt, ok := source.(*Statement)
+	if !ok {
+		return false
+	}
+	prev := p.cur
+	p.cur = stmt
+	defer func() { p.cur = prev }()
+
+	clauses := stmt.BuildClauses
+	if len(clauses) == 0 {
+		clauses = queryClauses
+	}
+	for _, name := range clauses {
+		c, ok := stmt.Clauses[name]
+		if !ok {
+			continue
+		}
+		p.Newline()
+		if build, ok := stmt.DB.ClauseBuilders[name]; ok {
+			build(c, p)
+		} else {
+			c.Build(p)
+		}
+	}
+	return true
+}
+
+func (p *prettyBuilder) WriteByte(c byte) error {
+	_, err := p.WriteString(string(c))
+	return err
+}
+
+// WriteString 逐个字符输出，gorm 的 clause.Expr 也是逐个字节写入原始 SQL 的
+func (p *prettyBuilder) WriteString(s string) (int, error) {
+	for i := 0; i < len(s); i++ {
+		p.writeChar(s[i])
+	}
+	return len(s), nil
+}
+
+// writeChar 引号中的字符串和标识符原样输出；引号外的单词缓存到单词结束时由 endWord 输出，
+// 空白合并为一个空格，超过行宽时在逗号之后折行
+func (p *prettyBuilder) writeChar(c byte) {
+	if p.quote != 0 {
+		p.writeRaw(string(c))
+		switch {
+		case p.escape:
+			p.escape = false
+		case c == '\\' && p.quote != '`':
+			p.escape = true
+		case c == p.quote:
+			p.quote = 0
+		}
+		return
+	}
+	if isWordChar(c) {
+		p.word = append(p.word, c)
+		return
+	}
+	p.endWord()
+	switch c {
+	case ' ', '\t', '\n', '\r':
+		p.spaces++
+		return
+	case '\'', '"', '`':
+		p.quote = c
+	}
+	p.flush()
+	p.writeRaw(string(c))
+	if p.opts.LineWidth > 0 && c == ',' && p.col >= p.opts.LineWidth {
+		p.breakLine()
+	}
+}
+
+// endWord 输出缓存的单词：超过行宽时在 AND、OR 之前折行，KeywordLower 时关键字转为小写
+func (p *prettyBuilder) endWord() {
+	if len(p.word) == 0 {
+		return
+	}
+	word := string(p.word)
+	p.word = p.word[:0]
+	if p.opts.LineWidth > 0 && p.col >= p.opts.LineWidth && (strings.EqualFold(word, "AND") || strings.EqualFold(word, "OR")) {
+		p.breakLine()
+	}
+	if p.opts.KeywordCase == KeywordLower && prettyKeywords[word] {
+		word = strings.ToLower(word)
+	}
+	p.flush()
+	p.writeRaw(word)
+}
+
+func (p *prettyBuilder) WriteQuoted(field any) {
+	if t, ok := field.(gormclause.Table); ok && t.Name == gormclause.CurrentTable && p.cur.TableExpr != nil {
+		// 子查询作为 FROM 的表时，按表达式输出以便缩进
+		p.cur.TableExpr.Build(p)
+		if t.Alias != "" {
+			p.endWord()
+			p.spaces++
+			p.WriteQuoted(gormclause.Table{Name: t.Alias, Raw: t.Raw})
+		}
+		return
+	}
+	// Raw 的表名（如 TN("t AS x")）中有关键字，按字符输出
+	var sb strings.Builder
+	p.cur.QuoteTo(&sb, field)
+	_, _ = p.WriteString(sb.String())
+}
+
+func (p *prettyBuilder) AddVar(writer gormclause.Writer, vars ...any) {
+	for i, v := range vars {
+		if i > 0 {
+			_ = p.WriteByte(',')
+		}
+		switch v := v.(type) {
+		case gormclause.Column, gormclause.Table:
+			p.WriteQuoted(v)
+		case gorm.Valuer:
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+				p.Statement.AddVar(p, nil)
+				continue
+			}
+			ctx := p.cur.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			p.AddVar(p, v.GormValue(ctx, p.cur.DB))
+		case gormclause.Interface:
+			c := gormclause.Clause{Name: v.Name()}
+			v.MergeClause(&c)
+			c.Build(p)
+		case gormclause.Expression:
+			v.Build(p)
+		case []any:
+			if len(v) == 0 {
+				_, _ = p.WriteString("(NULL)")
+				continue
+			}
+			_ = p.WriteByte('(')
+			p.AddVar(p, v...)
+			_ = p.WriteByte(')')
+		default:
+			p.Statement.AddVar(p, v)
+		}
+	}
+}
+
+// breakLine 超过行宽时换行，续行多缩进一层
+func (p *prettyBuilder) breakLine() {
+	p.newline = true
+	p.wrap = true
+}
+
+func (p *prettyBuilder) flush() {
+	if p.newline {
+		p.newline = false
+		p.spaces = 0
+		p.Statement.SQL.WriteByte('\n')
+		p.col = 0
+		indent := p.indent
+		if p.wrap {
+			indent++
+		}
+		p.writeRaw(strings.Repeat(p.opts.Indent, indent))
+		return
+	}
+	if p.spaces > 0 && p.SQL.Len() > 0 {
+		p.writeRaw(" ")
+	}
+	p.spaces = 0
+}
+
+func (p *prettyBuilder) writeRaw(s string) {
+	p.Statement.SQL.WriteString(s)
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		p.col = len(s) - i - 1
+	} else {
+		p.col += len(s)
+	}
+}
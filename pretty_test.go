@@ -0,0 +1,146 @@
+package gsql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+)
+
+func TestToPrettySQL_SubqueryJoinCase(t *testing.T) {
+	id := gsql.IntFieldOf[int64]("users", "id")
+	name := gsql.StringFieldOf[string]("users", "name")
+	amount := gsql.IntFieldOf[int64]("orders", "amount")
+	userID := gsql.IntFieldOf[int64]("orders", "user_id")
+	orders := gsql.TN("orders")
+
+	level := gsql.Case[gsql.StringExpr[string]]().
+		When(amount.Gt(1000), gsql.StringVal("VIP")).
+		Else(gsql.StringVal("Basic"))
+	paid := gsql.Select(gsql.Lit(1).As("_")).
+		From(gsql.TN("payments")).
+		Where(gsql.Expr("`payments`.`order_id` = `orders`.`id`"))
+
+	got := gsql.Select(id, name, level.As("level")).
+		From(gsql.TN("users")).
+		Join(gsql.LeftJoin(orders).On(userID.EqF(id))).
+		Where(name.Eq("a"), gsql.Exists(paid)).
+		Order(id, true).
+		ToPrettySQL(gsql.PrettyOptions{})
+
+	want := strings.Join([]string{
+		"SELECT `users`.`id`, `users`.`name`, CASE",
+		"  WHEN `orders`.`amount` > 1000 THEN 'VIP'",
+		"  ELSE 'Basic'",
+		"END AS `level`",
+		"FROM `users`",
+		"LEFT JOIN `orders` ON `orders`.`user_id` = `users`.`id`",
+		"WHERE `users`.`name` = 'a' AND EXISTS (",
+		"  SELECT 1 AS `_`",
+		"  FROM `payments`",
+		"  WHERE `payments`.`order_id` = `orders`.`id`",
+		")",
+		"ORDER BY `users`.`id`",
+	}, "\n")
+	if got != want {
+		t.Errorf("ToPrettySQL:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestToPrettySQL_CTEAndUnion(t *testing.T) {
+	got := gsql.With("a", gsql.Select(gsql.Field("id")).From(gsql.TN("users"))).
+		And("b", gsql.Select(gsql.Field("id")).From(gsql.TN("admins"))).
+		Select(gsql.Star).
+		From(gsql.TN("a")).
+		ToPrettySQL(gsql.PrettyOptions{KeywordCase: gsql.KeywordLower})
+
+	want := strings.Join([]string{
+		"with `a` as (",
+		"  select `id`",
+		"  from `users`",
+		"),",
+		"`b` as (",
+		"  select `id`",
+		"  from `admins`",
+		")",
+		"select *",
+		"from `a`",
+	}, "\n")
+	if got != want {
+		t.Errorf("ToPrettySQL:\n%s\nwant:\n%s", got, want)
+	}
+
+	union := gsql.UnionAll(
+		gsql.Select(gsql.Field("id")).From(gsql.TN("users")),
+		gsql.Select(gsql.Field("id")).From(gsql.TN("admins")),
+	)
+	got = gsql.PrettySQL(union.ToExpr(), gsql.PrettyOptions{})
+	want = strings.Join([]string{
+		"(",
+		"  SELECT `id`",
+		"  FROM `users`",
+		")",
+		"UNION ALL",
+		"(",
+		"  SELECT `id`",
+		"  FROM `admins`",
+		")",
+	}, "\n")
+	if got != want {
+		t.Errorf("PrettySQL:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestToPrettySQL_LineWidth(t *testing.T) {
+	a := gsql.IntFieldOf[int64]("t", "aaaaaaaa")
+	b := gsql.IntFieldOf[int64]("t", "bbbbbbbb")
+	c := gsql.IntFieldOf[int64]("t", "cccccccc")
+
+	got := gsql.Select(a, b, c).
+		From(gsql.TN("t")).
+		Where(a.Eq(1), b.Eq(2), c.Eq(3)).
+		ToPrettySQL(gsql.PrettyOptions{LineWidth: 30})
+
+	for _, line := range strings.Split(got, "\n") {
+		if len(line) > 30+len("`t`.`cccccccc` = 3") {
+			t.Errorf("line too long: %q", line)
+		}
+	}
+	t.Logf("pretty SQL:\n%s", got)
+	if !strings.Contains(got, "\n  AND ") {
+		t.Errorf("expected wrapped AND:\n%s", got)
+	}
+	// 折行只影响格式，去掉换行缩进后与 ToSQL 相同
+	flat := strings.Join(strings.Fields(got), " ")
+	want := strings.Join(strings.Fields(gsql.Select(a, b, c).From(gsql.TN("t")).Where(a.Eq(1), b.Eq(2), c.Eq(3)).ToSQL()), " ")
+	if flat != want {
+		t.Errorf("pretty SQL differs from ToSQL:\n%s\n%s", flat, want)
+	}
+
+	// 原始 Expr 与带别名的表中的关键字同样转换大小写并折行，引号中的内容不变
+	bb := balanceRecordSchemaBase.As("bb")
+	query := gsql.Select(bb.ID).
+		From(bb).
+		Where(gsql.Expr("note = ? AND bb.`key` IS NOT NULL AND amount IN (1, 2, 3) OR flag = 'AND NOT'", "x AND y"))
+	got = query.ToPrettySQL(gsql.PrettyOptions{KeywordCase: gsql.KeywordLower, LineWidth: 30})
+	t.Logf("pretty SQL:\n%s", got)
+	for _, expect := range []string{
+		"from balance_records as bb",
+		"where note = 'x AND y' and bb.`key` is not null",
+		"\n  and amount in (1, 2, 3) or flag = 'AND NOT'",
+	} {
+		if !strings.Contains(got, expect) {
+			t.Errorf("expected %q in:\n%s", expect, got)
+		}
+	}
+	for _, kw := range []string{"SELECT", "FROM", " AS ", "WHERE", " AND bb", " IS ", "NULL", " IN ", " OR "} {
+		if strings.Contains(got, kw) {
+			t.Errorf("keyword %q not lowercased:\n%s", kw, got)
+		}
+	}
+	flat = strings.Join(strings.Fields(got), " ")
+	want = strings.Join(strings.Fields(query.ToSQL()), " ")
+	if !strings.EqualFold(flat, want) {
+		t.Errorf("pretty SQL differs from ToSQL:\n%s\n%s", flat, want)
+	}
+}
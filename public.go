@@ -52,10 +52,22 @@ func DefineTable[Model any, ModelT any](tableName string, types ModelT, builder
 		}
 	}
 
-	var newTable = reflect.ValueOf(tableNameFn(tableName))
 	var ty = &types
+	withTableFields(ty, tableName)
+
+	return templateTable[ModelT, Model]{
+		Fields:    *ty,
+		tableName: tableName,
+		expr:      builder.ToExpr(),
+	}
+}
 
-	rv := reflect.ValueOf(ty)
+// withTableFields 将结构体中所有字段重新绑定到指定表名（或别名）
+// ptr 必须为指向结构体的指针
+func withTableFields(ptr any, tableName string) {
+	var newTable = reflect.ValueOf(tableNameFn(tableName))
+
+	rv := reflect.ValueOf(ptr)
 	if rv.Kind() != reflect.Ptr {
 		panic("input must be a pointer")
 	}
@@ -92,12 +104,6 @@ func DefineTable[Model any, ModelT any](tableName string, types ModelT, builder
 			}
 		}
 	}
-
-	return templateTable[ModelT, Model]{
-		Fields:    *ty,
-		tableName: tableName,
-		expr:      builder.ToExpr(),
-	}
 }
 
 var (
@@ -1,7 +1,10 @@
 package gsql
 
 import (
+	"context"
+	"errors"
 	"slices"
+	"time"
 
 	"github.com/donutnomad/gsql/clause"
 	"github.com/donutnomad/gsql/field"
@@ -81,6 +84,12 @@ func (b *QueryBuilder) Clone() *QueryBuilder {
 		from:    b.from,
 		joins:   slices.Clone(b.joins),
 		wheres:  slices.Clone(b.wheres),
+		ctx:     b.ctx,
+		timeout: b.timeout,
+		hints:   slices.Clone(b.hints),
+		tags:    b.tags,
+
+		allowFullTable: b.allowFullTable,
 	}
 }
 
@@ -264,7 +273,10 @@ func (b *QueryBuilder) Create(db IDB, value any) DBResult {
 	builder.selects = nil
 	builder.wheres = nil
 	builder.from = TN("")
-	ret := builder.build(db).Create(value)
+	tx := builder.build(db)
+	obs := beginExec(tx, OpInsert, "")
+	ret := tx.Create(value)
+	obs.endTx(ret)
 	return DBResult{
 		ret.Error,
 		ret.RowsAffected,
@@ -280,9 +292,16 @@ func (b *QueryBuilder) UpdateColumns(db IDB, value map[string]any) DBResult {
 }
 
 func (b *QueryBuilder) Delete(db IDB, dest any) DBResult {
-	ret := b.build(db).Delete(&dest)
+	tx, cancel := b.as().buildExec(db)
+	defer cancel()
+	if err := b.as().checkSafety(tx, OpDelete); err != nil {
+		return DBResult{err, 0}
+	}
+	obs := beginExec(tx, OpDelete, tx.Statement.Table)
+	ret := tx.Delete(&dest)
+	obs.endTx(ret)
 	return DBResult{
-		ret.Error,
+		b.as().timeoutError(tx, ret.Error),
 		ret.RowsAffected,
 	}
 }
@@ -307,20 +326,46 @@ func (b *QueryBuilder) Last(db IDB, dest any) error {
 	return firstLast(b.as(), db, true, true, dest)
 }
 
+// WithContext 设置执行时使用的 context
+func (b *QueryBuilder) WithContext(ctx context.Context) *QueryBuilder {
+	b.ctx = ctx
+	return b
+}
+
+// Timeout 设置查询的最长执行时间，见 QueryBuilderG.Timeout
+func (b *QueryBuilder) Timeout(d time.Duration) *QueryBuilder {
+	b.timeout = d
+	return b
+}
+
+// AllowFullTable 允许没有 WHERE 条件的 UPDATE/DELETE，见 QueryBuilderG.AllowFullTable
+func (b *QueryBuilder) AllowFullTable() *QueryBuilder {
+	b.allowFullTable = true
+	return b
+}
+
+// Tag 设置 SQL 注释标签，见 Commenter
+func (b *QueryBuilder) Tag(key, value string) *QueryBuilder {
+	b.as().Tag(key, value)
+	return b
+}
+
 func (b *QueryBuilder) Debug() *QueryBuilder {
 	b.logLevel = int(LogLevelInfo)
 	return b
 }
 
 func (b *QueryBuilder) Find(db IDB, dest any) error {
-	tx := b.build(db)
+	tx, cancel := b.as().buildExec(db)
+	defer cancel()
+	if err := b.as().checkSafety(tx, OpSelect); err != nil {
+		return err
+	}
 	ret := Scan(b.logLevel, tx, dest)
-	if ret.RowsAffected == 0 {
-		return nil
-	} else if ret.Error != nil {
-		return ret.Error
+	if ret.Error != nil && !errors.Is(ret.Error, ErrRecordNotFound) {
+		return b.as().timeoutError(tx, ret.Error)
 	}
-	return ret.Error
+	return nil
 }
 
 func (b *QueryBuilder) As(asName string) field.IField {
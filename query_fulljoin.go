@@ -0,0 +1,264 @@
+package gsql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/internal/clauses2"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+// ErrFullJoinEmulation MySQL 上无法改写的 FULL OUTER JOIN：
+// 一个查询有多个 FULL OUTER JOIN，无法从 ON/USING 中确定左侧的连接列，ORDER BY 中有引用带表名列的表达式，
+// 或查询带有 DISTINCT、GROUP BY、HAVING、聚合函数（两半分别去重、分组和聚合会得到拆开的部分结果）
+var ErrFullJoinEmulation = errors.New("full outer join cannot be emulated on MySQL")
+
+// emulateFullJoin 判断是否需要改写 FULL OUTER JOIN：MySQL 不支持，PostgreSQL、SQLite 等原生输出
+// 无法改写时按原样输出，执行时由 fullJoinError 返回错误
+func (b *QueryBuilderG[T]) emulateFullJoin(d gorm.Dialector) bool {
+	if d == nil || d.Name() != "mysql" {
+		return false
+	}
+	_, err := b.fullJoinLeftKey()
+	return err == nil
+}
+
+// fullJoinError MySQL 上存在无法改写的 FULL OUTER JOIN 时返回 ErrFullJoinEmulation
+func (b *QueryBuilderG[T]) fullJoinError(d gorm.Dialector) error {
+	if d == nil || d.Name() != "mysql" || !b.hasFullJoin() {
+		return nil
+	}
+	_, err := b.fullJoinLeftKey()
+	return err
+}
+
+func (b *QueryBuilderG[T]) hasFullJoin() bool {
+	return slices.ContainsFunc(b.joins, func(j JoinClause) bool {
+		return j.JoinType == fullOuterJoin
+	})
+}
+
+// fullJoinLeftKey 返回 FULL OUTER JOIN 左侧参与连接的列，用于在 RIGHT JOIN 一半中排除已匹配的行
+// ON 条件中取第一个不属于被连接表的列，USING 取 FROM 表的同名列
+func (b *QueryBuilderG[T]) fullJoinLeftKey() (*clauses2.ColumnQuote, error) {
+	joins := lo.Filter(b.joins, func(j JoinClause, _ int) bool {
+		return j.JoinType == fullOuterJoin
+	})
+	switch {
+	case len(joins) == 0:
+		return nil, fmt.Errorf("%w: no FULL OUTER JOIN", ErrFullJoinEmulation)
+	case len(joins) > 1:
+		return nil, fmt.Errorf("%w: only one FULL OUTER JOIN per query is supported", ErrFullJoinEmulation)
+	}
+	switch {
+	case b.distinct:
+		return nil, fmt.Errorf("%w: DISTINCT is not supported", ErrFullJoinEmulation)
+	case len(b.groupBy) > 0:
+		return nil, fmt.Errorf("%w: GROUP BY is not supported", ErrFullJoinEmulation)
+	case len(b.having) > 0:
+		return nil, fmt.Errorf("%w: HAVING is not supported", ErrFullJoinEmulation)
+	}
+	for _, f := range b.selects {
+		if name, ok := aggregateCall(Fingerprint(f)); ok {
+			return nil, fmt.Errorf("%w: aggregate function %s is not supported", ErrFullJoinEmulation, name)
+		}
+	}
+	for _, order := range b.orders {
+		if _, err := unionOrderExpr(order.field); err != nil {
+			return nil, err
+		}
+	}
+	j := joins[0]
+	if len(j.using) > 0 {
+		return &clauses2.ColumnQuote{TableName: tableRefName(b.from), ColumnName: columnName(j.using[0])}, nil
+	}
+	right := tableRefName(j.Table)
+	var key *clauses2.ColumnQuote
+	walkColumns(j.On, func(table, column string) bool {
+		if table == "" || table == right {
+			return true
+		}
+		key = &clauses2.ColumnQuote{TableName: table, ColumnName: column}
+		return false
+	})
+	if key == nil {
+		return nil, fmt.Errorf("%w: no left-side column in ON condition of `%s`", ErrFullJoinEmulation, right)
+	}
+	return key, nil
+}
+
+// aggregateFuncs 在 UNION ALL 两半中分别计算会得到部分结果的聚合函数
+var aggregateFuncs = map[string]struct{}{
+	"AVG": {}, "BIT_AND": {}, "BIT_OR": {}, "BIT_XOR": {}, "COUNT": {}, "GROUP_CONCAT": {},
+	"JSON_ARRAYAGG": {}, "JSON_OBJECTAGG": {}, "MAX": {}, "MIN": {}, "STD": {}, "STDDEV": {},
+	"STDDEV_POP": {}, "STDDEV_SAMP": {}, "SUM": {}, "VAR_POP": {}, "VAR_SAMP": {}, "VARIANCE": {},
+}
+
+// aggregateCall 返回 sql 中第一个聚合函数调用的函数名，sql 为 Fingerprint 的结果，字面量已替换为 ?
+// 反引号和双引号中的标识符不参与匹配
+func aggregateCall(sql string) (string, bool) {
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '`' || c == '"':
+			for i++; i < len(sql) && sql[i] != c; i++ {
+			}
+		case isWordChar(c):
+			end := i
+			for end < len(sql) && isWordChar(sql[end]) {
+				end++
+			}
+			name := strings.ToUpper(sql[i:end])
+			next := strings.TrimLeft(sql[end:], " ")
+			if _, ok := aggregateFuncs[name]; ok && strings.HasPrefix(next, "(") && (i == 0 || sql[i-1] != '.') {
+				return name, true
+			}
+			i = end - 1
+		}
+	}
+	return "", false
+}
+
+// tableRefName 表在 SQL 中被引用的名字，有别名时为别名
+func tableRefName(t ITableName) string {
+	if t == nil {
+		return ""
+	}
+	if v, ok := t.(interface{ Alias() string }); ok && v.Alias() != "" {
+		return v.Alias()
+	}
+	return t.TableName()
+}
+
+// walkColumns 按出现顺序遍历表达式中的列，fn 返回 false 时停止
+func walkColumns(v any, fn func(table, column string) bool) bool {
+	switch c := v.(type) {
+	case nil:
+		return true
+	case *clauses2.ColumnQuote:
+		return fn(c.TableName, c.ColumnName)
+	case clause.Column:
+		return fn(c.Table, c.Name)
+	case clause.Expr:
+		return walkColumns(c.Vars, fn)
+	case clause.RawExpr:
+		return walkColumns(c.Vars, fn)
+	case *Statement, *GormDB:
+		return true
+	case interface {
+		TableName() string
+		ColumnName() string
+	}:
+		return fn(c.TableName(), c.ColumnName())
+	case interface{ Unwrap() clause.Expression }:
+		return walkColumns(c.Unwrap(), fn)
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return true
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if f := rv.Field(i); rv.Type().Field(i).IsExported() && !walkColumns(f.Interface(), fn) {
+				return false
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if !walkColumns(rv.Index(i).Interface(), fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fullJoinExpr 将 FULL OUTER JOIN 改写为
+// [WITH ...] (SELECT ... LEFT JOIN ...) UNION ALL (SELECT ... RIGHT JOIN ... WHERE <左侧连接列> IS NULL) [ORDER BY ...] [LIMIT ...]
+// RIGHT JOIN 一半只保留左侧没有匹配的行，两半不重叠，原结果中的重复行得以保留
+func (b *QueryBuilderG[T]) fullJoinExpr() clause.Expr {
+	tx := newExprDB()
+	fullJoinUnion[T]{b}.Build(tx.Statement)
+	return clause.Expr{SQL: tx.Statement.SQL.String(), Vars: tx.Statement.Vars}
+}
+
+// fullJoinUnion 改写 FULL OUTER JOIN 后的语句，见 fullJoinExpr
+// 作为表达式构建时两半按子句输出，指纹中的值列表可以按结构合并
+type fullJoinUnion[T any] struct {
+	b *QueryBuilderG[T]
+}
+
+func (u fullJoinUnion[T]) Build(builder clause.Builder) {
+	b := u.b
+	leftKey, _ := b.fullJoinLeftKey()
+	var halves []Expression
+	for _, joinType := range []string{"LEFT JOIN", "RIGHT JOIN"} {
+		q := b.Clone()
+		q.cte, q.orders, q.offset, q.limit = nil, nil, 0, 0
+		q.joins = slices.Clone(b.joins)
+		for i := range q.joins {
+			if q.joins[i].JoinType == fullOuterJoin {
+				q.joins[i].JoinType = joinType
+			}
+		}
+		if joinType == "RIGHT JOIN" {
+			q.wheres = append(q.wheres, clause.Expr{SQL: "? IS NULL", Vars: []any{leftKey}})
+		}
+		halves = append(halves, q.ToExpr())
+	}
+
+	if b.cte != nil && len(b.cte.CTEs) > 0 {
+		b.cte.Build(builder)
+		_ = builder.WriteByte(' ')
+	}
+	unionClause{Exprs: halves}.Build(builder)
+
+	var orderBy clause.OrderBy
+	for _, order := range b.orders {
+		expr, _ := unionOrderExpr(order.field) // 已在 fullJoinLeftKey 中检查
+		orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{
+			Expr: expr,
+			Desc: !order.asc,
+		})
+	}
+	if len(orderBy.Columns) > 0 {
+		_, _ = builder.WriteString(" ORDER BY ")
+		orderBy.Build(builder)
+	}
+	if b.offset > 0 || b.limit > 0 {
+		limit := clause.Limit{Offset: b.offset}
+		if b.limit > 0 {
+			limit.Limit = &b.limit
+		}
+		_ = builder.WriteByte(' ')
+		limit.Build(builder)
+	}
+}
+
+// unionOrderExpr 返回 UNION ALL 之后的 ORDER BY 使用的表达式
+// MySQL 不允许其中引用带表名的列：列改为按别名或列名引用结果列，其他表达式中有带表名的列时无法改写
+func unionOrderExpr(expr clause.Expression) (clause.Expression, error) {
+	if c := clauses2.ColumnOf(expr); c != nil {
+		return &clauses2.ColumnQuote{ColumnName: lo.Ternary(c.Alias != "", c.Alias, c.ColumnName)}, nil
+	}
+	var qualified string
+	walkColumns(expr, func(table, column string) bool {
+		if table != "" {
+			qualified = table + "." + column
+		}
+		return qualified == ""
+	})
+	if qualified != "" {
+		return nil, fmt.Errorf("%w: ORDER BY references table column `%s`", ErrFullJoinEmulation, qualified)
+	}
+	return expr, nil
+}
@@ -1,11 +1,13 @@
 package gsql
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/donutnomad/gsql/clause"
 	"github.com/donutnomad/gsql/field"
@@ -43,6 +45,16 @@ type QueryBuilderG[T any] struct {
 	// CTE (Common Table Expression)
 	cte      *CTEClause
 	logLevel int
+	// 执行时使用的 context
+	ctx context.Context
+	// 查询的最长执行时间
+	timeout time.Duration
+	// 优化器提示 /*+ ... */
+	hints []OptimizerHint
+	// SQL 注释标签，见 Commenter
+	tags map[string]string
+	// 允许没有 WHERE 条件的 UPDATE/DELETE，见 AllowFullTable
+	allowFullTable bool
 }
 
 func SelectG[T any](fields ...field.IField) *baseQueryBuilderG[T] {
@@ -134,12 +146,18 @@ func (b *QueryBuilderG[T]) Clone() *QueryBuilderG[T] {
 		offset:         b.offset,
 		limit:          b.limit,
 		unscoped:       b.unscoped,
+		distinct:       b.distinct,
 		groupBy:        slices.Clone(b.groupBy),
 		having:         slices.Clone(b.having),
 		locking:        b.locking,
 		fromIndexHints: slices.Clone(b.fromIndexHints),
 		fromPartitions: slices.Clone(b.fromPartitions),
 		cte:            cte,
+		ctx:            b.ctx,
+		timeout:        b.timeout,
+		hints:          slices.Clone(b.hints),
+		tags:           b.tags,
+		allowFullTable: b.allowFullTable,
 	}
 }
 
@@ -331,45 +349,77 @@ func (b *QueryBuilderG[T]) Create(db IDB, value *T) DBResult {
 	builder.selects = nil
 	builder.wheres = nil
 	builder.from = TN("")
-	ret := builder.build(db).Create(value)
+	tx := builder.build(db)
+	obs := beginExec(tx, OpInsert, "")
+	ret := tx.Create(value)
+	obs.endTx(ret)
 	return DBResult{
 		ret.Error,
 		ret.RowsAffected,
 	}
 }
 
+// Update values 可以是 map、结构体，也可以是 gsql.Set、EnumField.Set 返回的 Assignment 及其切片
 func (b *QueryBuilderG[T]) Update(db IDB, values any) DBResult {
 	switch v := values.(type) {
 	case interface{ Build() map[string]any }:
 		values = v.Build()
+	case Assignment:
+		values = assignmentsToMap(v)
+	case []Assignment:
+		values = assignmentsToMap(v...)
 	}
 	if v, ok := values.(map[string]any); ok {
 		if len(v) == 0 {
 			return DBResult{nil, 0}
 		}
 	}
-	ret := b.build(db).Updates(values)
+	tx, cancel := b.buildExec(db)
+	defer cancel()
+	if err := b.checkSafety(tx, OpUpdate); err != nil {
+		return DBResult{err, 0}
+	}
+	obs := beginExec(tx, OpUpdate, tx.Statement.Table)
+	ret := tx.Updates(values)
+	obs.endTx(ret)
 	return DBResult{
-		ret.Error,
+		b.timeoutError(tx, ret.Error),
 		ret.RowsAffected,
 	}
 }
 
+// assignmentsToMap 将 gsql.Set 等赋值表达式转换为 Updates 使用的 map
+func assignmentsToMap(assignments ...Assignment) map[string]any {
+	ret := make(map[string]any, len(assignments))
+	for _, a := range assignments {
+		ret[columnName(a.Column)] = a.Value
+	}
+	return ret
+}
+
 func (b *QueryBuilderG[T]) UpdateColumns(db IDB, value map[string]any) DBResult {
 	return b.Update(db, value)
 }
 
 func (b *QueryBuilderG[T]) Delete(db IDB) DBResult {
 	var dest T
-	ret := b.build(db).Delete(&dest)
+	tx, cancel := b.buildExec(db)
+	defer cancel()
+	if err := b.checkSafety(tx, OpDelete); err != nil {
+		return DBResult{err, 0}
+	}
+	obs := beginExec(tx, OpDelete, tx.Statement.Table)
+	ret := tx.Delete(&dest)
+	obs.endTx(ret)
 	return DBResult{
-		ret.Error,
+		b.timeoutError(tx, ret.Error),
 		ret.RowsAffected,
 	}
 }
 
 func (b *QueryBuilderG[T]) Count(db IDB) (count int64, _ error) {
-	tx := b.build(db)
+	tx, cancel := b.buildExec(db)
+	defer cancel()
 	// 设置 Model 以支持软删除过滤
 	// GORM 的软删除机制需要 Model 来解析 schema 并添加 deleted_at IS NULL 条件
 	if lo.IsNil(tx.Statement.Model) {
@@ -377,8 +427,20 @@ func (b *QueryBuilderG[T]) Count(db IDB) (count int64, _ error) {
 			tx.Statement.Model = v.ModelTypeAny()
 		}
 	}
+	obs := beginExec(tx, OpSelect, tx.Statement.Table)
+	if b.emulateFullJoin(tx.Dialector) {
+		q := b.Clone()
+		q.orders, q.offset, q.limit = nil, 0, 0
+		expr := clause.Expr{SQL: "SELECT COUNT(*) FROM (?) AS `full_join`", Vars: []any{fullJoinUnion[T]{q}}}
+		raw := tx.Raw(expr.SQL, expr.Vars...)
+		setFingerprint(raw.Statement, Fingerprint(expr))
+		ret := raw.Scan(&count)
+		obs.endTx(ret)
+		return count, b.timeoutError(tx, ret.Error)
+	}
 	ret := tx.Count(&count)
-	return count, ret.Error
+	obs.endTx(ret)
+	return count, b.timeoutError(tx, ret.Error)
 }
 
 func (b *QueryBuilderG[T]) Exist(db IDB) (bool, error) {
@@ -403,15 +465,20 @@ func (b *QueryBuilderG[T]) Last(db IDB) (*T, error) {
 
 func (b *QueryBuilderG[T]) Find(db IDB) ([]*T, error) {
 	var dest []*T
-	tx := b.build(db)
+	tx, cancel := b.buildExec(db)
+	defer cancel()
+	if err := b.checkSafety(tx, OpSelect); err != nil {
+		return nil, err
+	}
 	//ret := tx.Find(&dest)
 	ret := Scan(b.logLevel, tx, &dest)
+	if ret.Error != nil && !errors.Is(ret.Error, ErrRecordNotFound) {
+		return nil, b.timeoutError(tx, ret.Error)
+	}
 	if ret.RowsAffected == 0 {
 		return nil, nil
-	} else if ret.Error != nil {
-		return nil, ret.Error
 	}
-	return dest, ret.Error
+	return dest, nil
 }
 
 func (b *QueryBuilderG[T]) As(asName string) field.IField {
@@ -445,6 +512,17 @@ func (b *QueryBuilderG[T]) firstLast(db IDB, order, desc bool) (*T, error) {
 }
 
 func (b *QueryBuilderG[T]) ToExpr() clause.Expr {
+	if b.emulateFullJoin(dialector) {
+		return b.fullJoinExpr()
+	}
+	tx := newExprDB()
+	b.buildStmt(tx.Statement, getQuoteFunc())
+	callbacks.BuildQuerySQL(tx)
+	return clause.Expr{SQL: tx.Statement.SQL.String(), Vars: tx.Statement.Vars, Source: tx.Statement}
+}
+
+// newExprDB 创建只用于生成 SQL 的会话
+func newExprDB() *GormDB {
 	tx := &GormDB{
 		Config: &Config{
 			ClauseBuilders: map[string]clause.ClauseBuilder{
@@ -462,9 +540,25 @@ func (b *QueryBuilderG[T]) ToExpr() clause.Expr {
 		},
 	}
 	tx.Statement.DB = tx
-	b.buildStmt(tx.Statement, getQuoteFunc())
-	callbacks.BuildQuerySQL(tx)
-	return clause.Expr{SQL: tx.Statement.SQL.String(), Vars: tx.Statement.Vars}
+	return tx
+}
+
+// selectFields 返回 SELECT 字段列表，用于 INSERT ... SELECT 的列映射校验
+func (b *QueryBuilderG[T]) selectFields() []field.IField {
+	return b.selects
+}
+
+// WithContext 设置执行时使用的 context
+// 取消和超时会传递到数据库驱动，日志和回调中可通过 Statement.Context 获取
+func (b *QueryBuilderG[T]) WithContext(ctx context.Context) *QueryBuilderG[T] {
+	b.ctx = ctx
+	return b
+}
+
+// Tag 设置 SQL 注释标签，注册了 Commenter 时追加到语句末尾，见 Commenter
+func (b *QueryBuilderG[T]) Tag(key, value string) *QueryBuilderG[T] {
+	b.tags = addTag(b.tags, key, value)
+	return b
 }
 
 func (b *QueryBuilderG[T]) Debug() *QueryBuilderG[T] {
@@ -475,6 +569,7 @@ func (b *QueryBuilderG[T]) Debug() *QueryBuilderG[T] {
 func (b *QueryBuilderG[T]) build(db IDB) *GormDB {
 	tx := db.Session(&Session{
 		Initialized: true,
+		Context:     b.ctx,
 	})
 	m := maps.Clone(tx.Config.ClauseBuilders)
 	m["CTE"] = func(c clause.Clause, builder clause.Builder) {
@@ -483,7 +578,22 @@ func (b *QueryBuilderG[T]) build(db IDB) *GormDB {
 		}
 	}
 	tx.Config.ClauseBuilders = m
+	if b.allowFullTable {
+		tx.Config.AllowGlobalUpdate = true
+	}
 	b.buildStmt(tx.Statement, getQuoteFunc())
+	if err := b.fullJoinError(tx.Dialector); err != nil {
+		_ = tx.AddError(err)
+	}
+	if err := hintsError(b.hints); err != nil {
+		_ = tx.AddError(err)
+	}
+	if b.emulateFullJoin(tx.Dialector) {
+		expr := b.fullJoinExpr()
+		tx = tx.Raw(expr.SQL, expr.Vars...)
+		// Raw 语句没有子句，执行时沿用按表达式计算的指纹
+		setFingerprint(tx.Statement, Fingerprint(fullJoinUnion[T]{b}))
+	}
 	if b.logLevel > 0 {
 		tx = tx.Session(&gorm.Session{
 			Logger: tx.Logger.LogMode(logger.LogLevel(b.logLevel)),
@@ -526,6 +636,7 @@ func (b *QueryBuilderG[T]) buildStmt(stmt *Statement, quote func(field string) s
 		}
 	}
 	addSelects(stmt, b.distinct, b.selects)
+	b.addOptimizerHints(stmt, quote)
 	if len(b.wheres) > 0 {
 		stmt.AddClause(clause.Where{Exprs: b.wheres})
 	}
@@ -563,6 +674,7 @@ func (b *QueryBuilderG[T]) buildStmt(stmt *Statement, quote func(field string) s
 	if b.locking != nil {
 		stmt.AddClause(*b.locking)
 	}
+	setTags(stmt, b.tags)
 }
 
 func asAny[OUT any, IN any](in *QueryBuilderG[IN]) *QueryBuilderG[OUT] {
@@ -583,6 +695,11 @@ func asAny[OUT any, IN any](in *QueryBuilderG[IN]) *QueryBuilderG[OUT] {
 		fromPartitions: in.fromPartitions,
 		cte:            in.cte,
 		logLevel:       in.logLevel,
+		ctx:            in.ctx,
+		timeout:        in.timeout,
+		hints:          in.hints,
+		tags:           in.tags,
+		allowFullTable: in.allowFullTable,
 	}
 }
 
@@ -603,6 +720,11 @@ func setTo[DST any, IN any](dst *QueryBuilderG[DST], src *QueryBuilderG[IN]) {
 	dst.fromPartitions = src.fromPartitions
 	dst.cte = src.cte
 	dst.logLevel = src.logLevel
+	dst.ctx = src.ctx
+	dst.timeout = src.timeout
+	dst.hints = src.hints
+	dst.tags = src.tags
+	dst.allowFullTable = src.allowFullTable
 }
 
 ////////////////////////////////////////////////
@@ -662,7 +784,8 @@ func buildPartitionSQL(quote func(string) string, parts []string) string {
 }
 
 func firstLast[T any](b *QueryBuilderG[T], db IDB, order, desc bool, dest any) error {
-	tx := b.Clone().Limit(1).build(db)
+	tx, cancel := b.Clone().Limit(1).buildExec(db)
+	defer cancel()
 	stmt := tx.Statement
 	stmt.RaiseErrorOnNotFound = true
 
@@ -686,7 +809,7 @@ func firstLast[T any](b *QueryBuilderG[T], db IDB, order, desc bool, dest any) e
 	stmt.RaiseErrorOnNotFound = true
 	ret := Scan(b.logLevel, tx, dest)
 	if err := ret.Error; err != nil {
-		return err
+		return b.timeoutError(tx, err)
 	}
 	return nil
 }
@@ -0,0 +1,128 @@
+package gsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// ErrQueryTimeout 查询超过 Timeout 设置的执行时间
+var ErrQueryTimeout = errors.New("query timeout")
+
+// ErrCodeQueryTimeout MySQL 超过 MAX_EXECUTION_TIME 时返回的错误码（ER_QUERY_TIMEOUT）
+const ErrCodeQueryTimeout uint16 = 3024
+
+// pgQueryCanceled PostgreSQL statement_timeout 触发时的 SQLSTATE
+const pgQueryCanceled = "57014"
+
+// Timeout 设置查询的最长执行时间
+//   - MySQL 在 SELECT 后输出 /*+ MAX_EXECUTION_TIME(ms) */ 优化器提示，由服务端中断超时的只读查询
+//   - PostgreSQL 在事务中执行时先执行 SET LOCAL statement_timeout，执行结束后恢复原值
+//   - 同时为本次执行派生相同时长的 context 截止时间，UPDATE/DELETE 依靠该截止时间中断
+//
+// 超时返回的错误可以用 errors.Is(err, gsql.ErrQueryTimeout) 判断
+//
+// 示例:
+//
+//	gsql.SelectG[User]().From(u).Where(...).Timeout(2 * time.Second).Find(db)
+func (b *QueryBuilderG[T]) Timeout(d time.Duration) *QueryBuilderG[T] {
+	b.timeout = d
+	return b
+}
+
+// maxExecutionTimeHint 设置了 Timeout 且为 MySQL 时返回 MAX_EXECUTION_TIME 提示
+func (b *QueryBuilderG[T]) maxExecutionTimeHint(stmt *Statement) (OptimizerHint, bool) {
+	if b.timeout <= 0 || stmt.DB == nil || stmt.DB.Dialector == nil || stmt.DB.Dialector.Name() != "mysql" {
+		return OptimizerHint{}, false
+	}
+	return HintMaxExecutionTime(b.timeout), true
+}
+
+// timeoutParentKey 派生超时 context 前的原始 context 在 Statement.Settings 中的键
+const timeoutParentKey = "gsql:timeout_parent"
+
+// buildExec 构建执行用的会话；设置了 Timeout 时派生带截止时间的 context，调用方需在执行结束后调用 cancel
+func (b *QueryBuilderG[T]) buildExec(db IDB) (*GormDB, context.CancelFunc) {
+	tx := b.build(db)
+	if b.timeout <= 0 {
+		return tx, func() {}
+	}
+	parent := tx.Statement.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, b.timeout)
+	tx.Statement.Context = ctx
+	tx.Statement.Settings.Store(timeoutParentKey, parent)
+
+	if tx.Dialector.Name() == "postgres" {
+		if committer, ok := tx.Statement.ConnPool.(gorm.TxCommitter); ok && committer != nil {
+			restore, err := setLocalStatementTimeout(tx.Session(&Session{NewDB: true, Context: parent}), b.timeout)
+			if err != nil {
+				_ = tx.AddError(err)
+			}
+			return tx, func() {
+				restore()
+				cancel()
+			}
+		}
+	}
+	return tx, cancel
+}
+
+// setLocalStatementTimeout 在 PostgreSQL 事务中设置 statement_timeout，返回恢复原值的函数
+// SET LOCAL 会一直生效到事务结束，执行完本次查询后需要恢复，避免影响同一事务中的后续语句；
+// 查询超时后事务已处于中止状态，恢复失败时忽略错误，由调用方回滚事务
+func setLocalStatementTimeout(tx *GormDB, d time.Duration) (restore func(), err error) {
+	var prev string
+	if err := tx.Raw("SHOW statement_timeout").Scan(&prev).Error; err != nil {
+		return func() {}, err
+	}
+	ms := max(d.Milliseconds(), 1)
+	if err := tx.Exec("SET LOCAL statement_timeout = " + strconv.FormatInt(ms, 10)).Error; err != nil {
+		return func() {}, err
+	}
+	return func() {
+		_ = tx.Exec("SET LOCAL statement_timeout = '" + strings.ReplaceAll(prev, "'", "''") + "'").Error
+	}, nil
+}
+
+// timeoutError 将由 Timeout 引起的错误包装为 ErrQueryTimeout
+// 调用方传入的 context 已结束（取消或更早的截止时间）时原样返回，只有派生的截止时间到期才算作查询超时
+func (b *QueryBuilderG[T]) timeoutError(tx *GormDB, err error) error {
+	if err == nil || b.timeout <= 0 {
+		return err
+	}
+	v, ok := tx.Statement.Settings.Load(timeoutParentKey)
+	if !ok {
+		return err
+	}
+	if parent := v.(context.Context); parent.Err() != nil {
+		return err
+	}
+	if !isServerTimeout(err) && !(errors.Is(err, context.DeadlineExceeded) && errors.Is(tx.Statement.Context.Err(), context.DeadlineExceeded)) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrQueryTimeout, err)
+}
+
+// isServerTimeout 判断错误是否为服务端执行超时（MAX_EXECUTION_TIME 或 statement_timeout）
+func isServerTimeout(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) && myErr.Number == ErrCodeQueryTimeout {
+		return true
+	}
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == pgQueryCanceled
+}
+
+// isQueryTimeout 判断错误是否由执行超时引起
+func isQueryTimeout(err error) bool {
+	return isServerTimeout(err) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,131 @@
+package gsql_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/gsqltest"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestTimeout_MaxExecutionTimeHint(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	sql := gsql.Select(table.ID).From(table).Where(table.ID.Gt(1)).Timeout(1500 * time.Millisecond).ToSQL()
+	expect := "SELECT /*+ MAX_EXECUTION_TIME(1500) */ `message_consumer_progress`.`id` FROM `message_consumer_progress`"
+	if !strings.HasPrefix(sql, expect) {
+		t.Errorf("期望以 %q 开头，实际: %s", expect, sql)
+	}
+
+	db, pool := openCaptureDB(t, "8.0.36")
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Timeout(time.Second).Find(db)
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Timeout(time.Second).Count(db)
+	if len(pool.sqls) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(pool.sqls))
+	}
+	for _, sql := range pool.sqls {
+		if !strings.HasPrefix(sql, "SELECT /*+ MAX_EXECUTION_TIME(1000) */ ") {
+			t.Errorf("缺少 MAX_EXECUTION_TIME 提示: %s", sql)
+		}
+	}
+	if _, ok := pool.ctx.Deadline(); !ok {
+		t.Errorf("期望 context 带有截止时间")
+	}
+}
+
+func TestTimeout_Error(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	db, pool := openCaptureDB(t, "8.0.36")
+	pool.err = &mysqldriver.MySQLError{Number: gsql.ErrCodeQueryTimeout, Message: "Query execution was interrupted, maximum statement execution time exceeded"}
+	_, err := gsql.SelectG[MessageConsumerProgress]().From(table).Timeout(time.Second).Find(db)
+	if !errors.Is(err, gsql.ErrQueryTimeout) {
+		t.Errorf("期望 ErrQueryTimeout，实际: %v", err)
+	}
+	var myErr *mysqldriver.MySQLError
+	if !errors.As(err, &myErr) {
+		t.Errorf("期望保留原始错误，实际: %v", err)
+	}
+
+	// 截止时间先于服务端超时到达
+	db, _ = openCaptureDB(t, "8.0.36")
+	ret := gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).Timeout(time.Nanosecond).Delete(db)
+	if !errors.Is(ret.Error, gsql.ErrQueryTimeout) {
+		t.Errorf("期望 ErrQueryTimeout，实际: %v", ret.Error)
+	}
+
+	// 调用方的截止时间先于 Timeout 到达，不属于查询超时
+	parent, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-parent.Done()
+	db, _ = openCaptureDB(t, "8.0.36")
+	ret = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).Timeout(time.Hour).Delete(db.WithContext(parent))
+	if !errors.Is(ret.Error, context.DeadlineExceeded) || errors.Is(ret.Error, gsql.ErrQueryTimeout) {
+		t.Errorf("期望原样返回 context.DeadlineExceeded，实际: %v", ret.Error)
+	}
+	db, pool = openCaptureDB(t, "8.0.36")
+	pool.err = &mysqldriver.MySQLError{Number: gsql.ErrCodeQueryTimeout}
+	_, err = gsql.SelectG[MessageConsumerProgress]().From(table).Timeout(time.Hour).Find(db.WithContext(parent))
+	if errors.Is(err, gsql.ErrQueryTimeout) {
+		t.Errorf("调用方 context 已结束时不应包装为 ErrQueryTimeout: %v", err)
+	}
+
+	// 未设置 Timeout 时不包装
+	db, pool = openCaptureDB(t, "8.0.36")
+	pool.err = &mysqldriver.MySQLError{Number: gsql.ErrCodeQueryTimeout}
+	_, err = gsql.SelectG[MessageConsumerProgress]().From(table).Find(db)
+	if errors.Is(err, gsql.ErrQueryTimeout) {
+		t.Errorf("未设置 Timeout 时不应包装为 ErrQueryTimeout")
+	}
+}
+
+func TestTimeout_PostgresStatementTimeout(t *testing.T) {
+	m := gsqltest.New(t, gsqltest.AllowUnexpected())
+	db, err := gorm.Open(postgresNamedDialector{mysql.New(mysql.Config{
+		Conn:                      m.DB().ConnPool,
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	}).(*mysql.Dialector)}, &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.ExpectQuery("SHOW statement_timeout").WillReturnRows(gsqltest.NewRows("statement_timeout").AddRow("5s"))
+	table := NewMessageConsumerProgressTable()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		_, err := gsql.SelectG[MessageConsumerProgress]().From(table).Timeout(time.Second).Find(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 执行结束后恢复原来的 statement_timeout，不影响同一事务中的后续语句
+	sqls := m.SQLs()
+	expect := []string{
+		"SHOW statement_timeout",
+		"SET LOCAL statement_timeout = 1000",
+		"SELECT",
+		"SET LOCAL statement_timeout = '5s'",
+	}
+	if len(sqls) != len(expect) {
+		t.Fatalf("期望 %d 条语句，实际: %q", len(expect), sqls)
+	}
+	for i, prefix := range expect {
+		if !strings.HasPrefix(sqls[i], prefix) {
+			t.Errorf("第 %d 条语句期望以 %q 开头，实际: %s", i+1, prefix, sqls[i])
+		}
+	}
+
+	// 不在事务中时不设置 statement_timeout
+	m.Reset()
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Timeout(time.Second).Find(db)
+	if sqls := m.SQLs(); len(sqls) != 1 || !strings.HasPrefix(sqls[0], "SELECT") {
+		t.Errorf("期望只执行 SELECT，实际: %q", sqls)
+	}
+}
@@ -0,0 +1,238 @@
+package gsql
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
+	"github.com/donutnomad/gsql/internal/clauses2"
+)
+
+// RedactedValue 默认的脱敏结果
+const RedactedValue = "[REDACTED]"
+
+// RedactFunc 返回脱敏后的参数值
+type RedactFunc func(value any) any
+
+// Redactor 按列对 SQL 参数脱敏，用于日志输出
+// 每个参数对应的列在构建语句时记录：比较条件、LIKE、INSERT 的 VALUES、UPDATE 的 SET
+// 以及 ON DUPLICATE KEY UPDATE 的赋值按其所在的列记录，赋值表达式中的参数记为被赋值的列。
+// 配置了列之后，无法确定列的参数（如 LIMIT ?、子查询中的参数、直接使用 gorm 执行的语句）一律脱敏，
+// 可以通过 Unmatched 修改这些参数的脱敏方式
+//
+// 示例:
+//
+//	redactor := gsql.NewRedactor().
+//		Sensitive(u).                          // 带有 field.FlagSensitive 标志的字段
+//		Column("phone", func(v any) any {      // 自定义脱敏方式
+//			return maskPhone(v)
+//		})
+type Redactor struct {
+	columns   map[string]RedactFunc
+	unmatched RedactFunc
+}
+
+func NewRedactor() *Redactor {
+	return &Redactor{columns: make(map[string]RedactFunc)}
+}
+
+// Column 对指定列的参数脱敏，fn 省略时替换为 RedactedValue
+func (r *Redactor) Column(name string, fn ...RedactFunc) *Redactor {
+	redact := RedactFunc(redactedValue)
+	if len(fn) > 0 && fn[0] != nil {
+		redact = fn[0]
+	}
+	r.columns[strings.ToLower(name)] = redact
+	return r
+}
+
+// Unmatched 设置无法确定列的参数的脱敏方式，fn 省略时替换为 RedactedValue
+// 未设置时，只要配置了列，这些参数就替换为 RedactedValue
+func (r *Redactor) Unmatched(fn ...RedactFunc) *Redactor {
+	r.unmatched = redactedValue
+	if len(fn) > 0 && fn[0] != nil {
+		r.unmatched = fn[0]
+	}
+	return r
+}
+
+// Sensitive 对表结构中带有 field.FlagSensitive 标志的字段脱敏
+// 字段列表优先使用 AllFields()，未实现时使用结构体中的所有字段
+func (r *Redactor) Sensitive(tables ...any) *Redactor {
+	for _, table := range tables {
+		for _, f := range tableFields(table) {
+			if !hasFieldFlag(f, field.FlagSensitive) {
+				continue
+			}
+			r.Column(columnName(f))
+		}
+	}
+	return r
+}
+
+// Redact 返回脱敏后的参数副本，不修改 vars
+// columns 为构建语句时记录的每个参数对应的列，空字符串或超出 columns 长度的参数视为无法确定列
+func (r *Redactor) Redact(columns []string, vars []any) []any {
+	if r == nil || (len(r.columns) == 0 && r.unmatched == nil) || len(vars) == 0 {
+		return vars
+	}
+	unmatched := r.unmatched
+	if unmatched == nil {
+		unmatched = redactedValue
+	}
+	var ret []any
+	for i := range vars {
+		fn := unmatched
+		if i < len(columns) && columns[i] != "" {
+			var ok bool
+			if fn, ok = r.columns[strings.ToLower(columns[i])]; !ok {
+				continue
+			}
+		}
+		if ret == nil {
+			ret = slices.Clone(vars)
+		}
+		ret[i] = fn(vars[i])
+	}
+	if ret == nil {
+		return vars
+	}
+	return ret
+}
+
+func redactedValue(any) any {
+	return RedactedValue
+}
+
+// boundCallKey context 中 boundCall 的键
+type boundCallKey struct{}
+
+// boundCall 一次 gsql 执行的参数列记录，以及 slog logger 在 ParamsFilter 中记录的占位符 SQL 和脱敏后的参数
+// 通过语句的 context 传给 logger，每次执行使用各自的 boundCall
+type boundCall struct {
+	bound *clauses2.BoundColumns
+	sql   string
+	vars  []any
+	ok    bool
+}
+
+// columns 返回 sql 对应的参数列，见 clauses2.BoundColumns.Columns
+func (c *boundCall) columns(sql string, params []any) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.bound.Columns(sql, len(params))
+}
+
+func boundCallFrom(ctx context.Context) *boundCall {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(boundCallKey{}).(*boundCall)
+	return c
+}
+
+// trackBoundColumns 使用 slog logger 时记录 tx 构建语句时每个参数对应的列，需要在构建语句之前调用
+// INSERT 的 VALUES 和 UPDATE 的 SET 由 gorm 构建，通过替换这两个子句的 ClauseBuilder 记录
+func trackBoundColumns(tx *GormDB) {
+	if tx == nil || tx.Config == nil {
+		return
+	}
+	if _, ok := tx.Config.Logger.(*slogLogger); !ok {
+		return
+	}
+	stmt := tx.Statement
+	if clauses2.LookupBoundColumns(stmt) != nil {
+		return
+	}
+	bound := &clauses2.BoundColumns{Stmt: stmt}
+	stmt.Settings.Store(clauses2.BoundColumnsKey, bound)
+	ctx := stmt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	stmt.Context = context.WithValue(ctx, boundCallKey{}, &boundCall{bound: bound})
+
+	config := *tx.Config
+	config.ClauseBuilders = maps.Clone(config.ClauseBuilders)
+	if config.ClauseBuilders == nil {
+		config.ClauseBuilders = make(map[string]clause.ClauseBuilder)
+	}
+	wrapClauseBuilder(config.ClauseBuilders, "VALUES", func(e clause.Expression) clause.Expression {
+		if v, ok := e.(clause.Values); ok && len(v.Columns) > 0 {
+			return boundValues(v)
+		}
+		return e
+	})
+	wrapClauseBuilder(config.ClauseBuilders, "SET", func(e clause.Expression) clause.Expression {
+		if v, ok := e.(clause.Set); ok && len(v) > 0 {
+			return boundSet(v)
+		}
+		return e
+	})
+	tx.Config = &config
+}
+
+// wrapClauseBuilder 构建 name 子句前使用 wrap 替换其表达式，再交给原有的 ClauseBuilder
+func wrapClauseBuilder(builders map[string]clause.ClauseBuilder, name string, wrap func(clause.Expression) clause.Expression) {
+	prev := builders[name]
+	builders[name] = func(c clause.Clause, builder clause.Builder) {
+		c.Expression = wrap(c.Expression)
+		if prev != nil {
+			prev(c, builder)
+		} else {
+			c.Build(builder)
+		}
+	}
+}
+
+// boundValues 与 clause.Values 相同，并按列记录每个参数
+type boundValues clause.Values
+
+func (v boundValues) Build(builder clause.Builder) {
+	builder.WriteByte('(')
+	for idx, column := range v.Columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(column)
+	}
+	builder.WriteString(") VALUES ")
+	for idx, row := range v.Values {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteByte('(')
+		for i, value := range row {
+			if i > 0 {
+				builder.WriteByte(',')
+			}
+			clauses2.BindVar(builder, columnAt(v.Columns, i), value)
+		}
+		builder.WriteByte(')')
+	}
+}
+
+// boundSet 与 clause.Set 相同，并将赋值中的参数记为被赋值的列
+type boundSet clause.Set
+
+func (s boundSet) Build(builder clause.Builder) {
+	for idx, assignment := range s {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(assignment.Column)
+		builder.WriteByte('=')
+		clauses2.BindVar(builder, assignment.Column.Name, assignment.Value)
+	}
+}
+
+func columnAt(columns []clause.Column, i int) string {
+	if i < len(columns) {
+		return columns[i].Name
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package gsql
+
+import (
+	"context"
+
+	"github.com/donutnomad/gsql/clause"
+	"github.com/donutnomad/gsql/field"
+)
+
+// ReplaceBuilder REPLACE INTO 构建器
+// 唯一性约束冲突时先删除旧行再插入新行，未提供值的列使用默认值
+type ReplaceBuilder[T any] struct {
+	selectColumns []field.IField
+}
+
+// ReplaceInto 创建 REPLACE INTO 语句，columns 为空时写入所有列
+// 示例:
+//
+//	gsql.ReplaceInto(t).Values(&rows).Exec(db)
+//	gsql.ReplaceInto(t, t.ID, t.Name).Select(gsql.Select(s.ID, s.Name).From(s)).Exec(db)
+func ReplaceInto[T any, TableTypes interface {
+	ModelType() T
+}](_ TableTypes, columns ...field.IField) *ReplaceBuilder[T] {
+	return &ReplaceBuilder[T]{
+		selectColumns: columns,
+	}
+}
+
+func (b *ReplaceBuilder[T]) Value(value T) *replaceBuilderWithValues[T] {
+	return b.Values(&[]T{value})
+}
+
+func (b *ReplaceBuilder[T]) Values(values *[]T) *replaceBuilderWithValues[T] {
+	return &replaceBuilderWithValues[T]{
+		b: &insertBuilderWithValues[T]{
+			selectColumns: b.selectColumns,
+			values:        values,
+			replace:       true,
+		},
+	}
+}
+
+// Select 使用 SELECT 查询结果替换，执行前校验目标列与 SELECT 字段的数量和类型
+func (b *ReplaceBuilder[T]) Select(q interface{ ToExpr() clause.Expr }) *replaceBuilderWithSelect[T] {
+	return &replaceBuilderWithSelect[T]{
+		b: newInsertBuilderWithSelect[T](b.selectColumns, q, false, true),
+	}
+}
+
+// replaceBuilderWithValues REPLACE INTO ... VALUES
+type replaceBuilderWithValues[T any] struct {
+	b *insertBuilderWithValues[T]
+}
+
+// WithContext 设置执行时使用的 context
+func (r *replaceBuilderWithValues[T]) WithContext(ctx context.Context) *replaceBuilderWithValues[T] {
+	r.b.WithContext(ctx)
+	return r
+}
+
+// Tag 设置 SQL 注释标签，见 Commenter
+func (r *replaceBuilderWithValues[T]) Tag(key, value string) *replaceBuilderWithValues[T] {
+	r.b.Tag(key, value)
+	return r
+}
+
+func (r *replaceBuilderWithValues[T]) Exec(db IGormDB) error {
+	return r.b.Exec(db)
+}
+
+func (r *replaceBuilderWithValues[T]) ExecWithResult(db IGormDB) (int64, error) {
+	return r.b.ExecWithResult(db)
+}
+
+// replaceBuilderWithSelect REPLACE INTO ... SELECT
+type replaceBuilderWithSelect[T any] struct {
+	b *insertBuilderWithSelect[T]
+}
+
+// WithContext 设置执行时使用的 context
+func (r *replaceBuilderWithSelect[T]) WithContext(ctx context.Context) *replaceBuilderWithSelect[T] {
+	r.b.WithContext(ctx)
+	return r
+}
+
+// Tag 设置 SQL 注释标签，见 Commenter
+func (r *replaceBuilderWithSelect[T]) Tag(key, value string) *replaceBuilderWithSelect[T] {
+	r.b.Tag(key, value)
+	return r
+}
+
+func (r *replaceBuilderWithSelect[T]) Exec(db IGormDB) error {
+	return r.b.Exec(db)
+}
+
+func (r *replaceBuilderWithSelect[T]) ExecWithResult(db IGormDB) (int64, error) {
+	return r.b.ExecWithResult(db)
+}
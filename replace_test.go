@@ -0,0 +1,169 @@
+package gsql_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"github.com/donutnomad/gsql/field"
+)
+
+func TestReplaceInto_Values(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, pool := openCaptureDB(t, "8.0.36")
+
+	rows := []MessageConsumerProgress{{ID: 1, GenerationID: 2}, {ID: 2, GenerationID: 3}}
+	err := gsql.ReplaceInto(table, table.ID, table.GenerationID).Values(&rows).Exec(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	// created_at、updated_at 由 gorm 自动写入
+	expect := "REPLACE INTO `message_consumer_progress` (`generation_id`,`created_at`,`updated_at`,`id`) VALUES (?,?,?,?),(?,?,?,?)"
+	if len(pool.sqls) != 1 || pool.sqls[0] != expect {
+		t.Errorf("期望 %q，实际: %v", expect, pool.sqls)
+	}
+}
+
+func TestReplaceInto_Select(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, pool := openCaptureDB(t, "8.0.36")
+
+	query := gsql.SelectG[MessageConsumerProgress](table.ID, table.ConsumerGroup, table.GenerationID.Add(1).As("generation_id")).
+		From(table).
+		Where(table.GenerationID.Gt(0))
+	err := gsql.ReplaceInto(table, table.ID, table.ConsumerGroup, table.GenerationID).Select(query).Exec(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if len(pool.sqls) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(pool.sqls))
+	}
+	expect := "REPLACE INTO `message_consumer_progress` (`id`,`consumer_group`,`generation_id`) SELECT "
+	if !strings.HasPrefix(pool.sqls[0], expect) {
+		t.Errorf("期望以 %q 开头，实际: %s", expect, pool.sqls[0])
+	}
+}
+
+func TestInsertSelect_ColumnMismatch(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	cases := []struct {
+		name    string
+		columns []field.IField
+		query   *gsql.QueryBuilderG[MessageConsumerProgress]
+	}{
+		{
+			name:    "arity",
+			columns: []field.IField{table.ID, table.GenerationID},
+			query:   gsql.SelectG[MessageConsumerProgress](table.ID).From(table),
+		},
+		{
+			name:    "string into int",
+			columns: []field.IField{table.ID, table.GenerationID},
+			query:   gsql.SelectG[MessageConsumerProgress](table.ID, table.ConsumerGroup).From(table),
+		},
+		{
+			name:    "datetime into int",
+			columns: []field.IField{table.GenerationID},
+			query:   gsql.SelectG[MessageConsumerProgress](table.CreatedAt).From(table),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, pool := openCaptureDB(t, "8.0.36")
+			err := gsql.InsertInto(table, tc.columns...).Select(tc.query).Exec(db)
+			if !errors.Is(err, gsql.ErrInsertColumnMismatch) {
+				t.Fatalf("期望 ErrInsertColumnMismatch，实际: %v", err)
+			}
+			if len(pool.sqls) != 0 {
+				t.Errorf("校验失败时不应执行 SQL，实际: %v", pool.sqls)
+			}
+		})
+	}
+}
+
+func TestInsertSelect_CompatibleColumns(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, pool := openCaptureDB(t, "8.0.36")
+
+	// 别名表达式与 SELECT * 无法推断类型，只校验能够识别的字段
+	query := gsql.SelectG[MessageConsumerProgress](table.ID.Add(100).As("id"), table.GenerationID.As("g")).From(table)
+	err := gsql.InsertInto(table, table.LastConsumedMessageID, table.GenerationID).Select(query).Exec(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	err = gsql.InsertInto(table).Select(gsql.SelectG[MessageConsumerProgress]().From(table)).Exec(db)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if len(pool.sqls) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(pool.sqls))
+	}
+}
+
+func TestInsertInto_UnknownColumn(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, _ := openCaptureDB(t, "8.0.36")
+
+	err := gsql.InsertInto(table, gsql.IntFieldOf[int64](table.TableName(), "missing")).
+		Value(MessageConsumerProgress{ID: 1}).
+		Exec(db)
+	if !errors.Is(err, gsql.ErrInsertColumnMismatch) {
+		t.Fatalf("期望 ErrInsertColumnMismatch，实际: %v", err)
+	}
+}
+
+func TestInsertInto_ColumnTypeMismatch(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+
+	cases := []struct {
+		name   string
+		column field.IField
+		ok     bool
+	}{
+		{name: "string into int", column: gsql.StringFieldOf[string](table.TableName(), "generation_id")},
+		{name: "int into time", column: gsql.IntFieldOf[int64](table.TableName(), "created_at")},
+		{name: "float into int", column: gsql.FloatFieldOf[float64](table.TableName(), "last_consumed_message_id")},
+		{name: "bool into int", column: gsql.BoolFieldOf[bool](table.TableName(), "generation_id"), ok: true},
+		{name: "scalar", column: gsql.ScalarFieldOf[int64](table.TableName(), "consumer_group"), ok: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, pool := openCaptureDB(t, "8.0.36")
+			err := gsql.InsertInto(table, table.ID, tc.column).
+				Value(MessageConsumerProgress{ID: 1}).
+				Exec(db)
+			if tc.ok {
+				if err != nil {
+					t.Fatalf("exec: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, gsql.ErrInsertColumnMismatch) {
+				t.Fatalf("期望 ErrInsertColumnMismatch，实际: %v", err)
+			}
+			if len(pool.sqls) != 0 {
+				t.Errorf("校验失败时不应执行 SQL，实际: %v", pool.sqls)
+			}
+		})
+	}
+}
+
+func TestInsertSelect_BoolAndEnumColumns(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	status := gsql.EnumFieldOf[orderStatus](table.TableName(), "consumer_group")
+	active := gsql.BoolFieldOf[bool](table.TableName(), "generation_id")
+
+	db, _ := openCaptureDB(t, "8.0.36")
+	query := gsql.SelectG[MessageConsumerProgress](status, active).From(table)
+	if err := gsql.InsertInto(table, table.ConsumerGroup, table.GenerationID).Select(query).Exec(db); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+
+	query = gsql.SelectG[MessageConsumerProgress](active).From(table)
+	err := gsql.InsertInto(table, table.CreatedAt).Select(query).Exec(db)
+	if !errors.Is(err, gsql.ErrInsertColumnMismatch) {
+		t.Fatalf("期望 ErrInsertColumnMismatch，实际: %v", err)
+	}
+}
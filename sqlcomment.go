@@ -0,0 +1,189 @@
+package gsql
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/donutnomad/gsql/clause"
+	"gorm.io/gorm"
+)
+
+// commentClauseName 语句末尾 SQL 注释子句的名字
+const commentClauseName = "gsql:comment"
+
+// commenterName Commenter 注册的插件名
+const commenterName = "gsql:commenter"
+
+// tagsSettingKey 构建器通过 Tag 设置的标签在 Statement.Settings 中的键
+const tagsSettingKey = "gsql:tags"
+
+// Commenter 在每条语句末尾追加 sqlcommenter 风格的注释，如 /*app='svc',route='%2Forders'*/
+// 默认不开启，通过 db.Use 注册后只对该连接及其会话生效
+// 标签来源按优先级从低到高：WithStaticTags、WithContextTagger、WithTags(ctx)、构建器的 Tag
+// 键和值按 URL 编码输出，值使用单引号包裹，标签按键排序
+// 示例:
+//
+//	db.Use(gsql.NewCommenter(gsql.WithStaticTags(map[string]string{"app": "svc"})))
+//	ctx = gsql.WithTags(ctx, "route", "/orders")
+//	gsql.SelectG[User]().From(u).WithContext(ctx).Tag("action", "list").Find(db)
+//	// SELECT * FROM `users` /*action='list',app='svc',route='%2Forders'*/
+type Commenter struct {
+	tags    map[string]string
+	taggers []func(ctx context.Context) map[string]string
+}
+
+// CommenterOption NewCommenter 的选项
+type CommenterOption func(c *Commenter)
+
+// WithStaticTags 每条语句都携带的固定标签
+func WithStaticTags(tags map[string]string) CommenterOption {
+	return func(c *Commenter) {
+		maps.Copy(c.tags, tags)
+	}
+}
+
+// WithContextTagger 从执行时的 context 中读取标签，如 trace_id
+func WithContextTagger(fn func(ctx context.Context) map[string]string) CommenterOption {
+	return func(c *Commenter) {
+		c.taggers = append(c.taggers, fn)
+	}
+}
+
+func NewCommenter(opts ...CommenterOption) *Commenter {
+	c := &Commenter{tags: make(map[string]string)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Commenter) Name() string {
+	return commenterName
+}
+
+func (c *Commenter) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// comment 合并所有来源的标签并返回注释，没有标签时返回空字符串
+func (c *Commenter) comment(ctx context.Context, builderTags map[string]string) string {
+	tags := maps.Clone(c.tags)
+	if ctx != nil {
+		for _, fn := range c.taggers {
+			maps.Copy(tags, fn(ctx))
+		}
+		maps.Copy(tags, TagsFromContext(ctx))
+	}
+	maps.Copy(tags, builderTags)
+	return FormatComment(tags)
+}
+
+type tagsKey struct{}
+
+// WithTags 返回携带 SQL 注释标签的 context，kv 为键值对，与 ctx 中已有的标签合并
+// 只有注册了 Commenter 的连接才会输出
+func WithTags(ctx context.Context, kv ...string) context.Context {
+	tags := maps.Clone(TagsFromContext(ctx))
+	if tags == nil {
+		tags = make(map[string]string, len(kv)/2)
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		tags[kv[i]] = kv[i+1]
+	}
+	return context.WithValue(ctx, tagsKey{}, tags)
+}
+
+// TagsFromContext 返回 WithTags 设置的标签，不要修改返回的 map
+func TagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return tags
+}
+
+// FormatComment 按 sqlcommenter 规范格式化标签，没有标签时返回空字符串
+// 键和值都经过 URL 编码，不会出现引号或 */，可以安全地拼接到 SQL 中
+func FormatComment(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("/*")
+	for i, k := range slices.Sorted(maps.Keys(tags)) {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(url.PathEscape(k))
+		sb.WriteString("='")
+		sb.WriteString(url.PathEscape(tags[k]))
+		sb.WriteByte('\'')
+	}
+	sb.WriteString("*/")
+	return sb.String()
+}
+
+// setTags 将构建器的标签保存到 Statement，执行时由 tagStatement 输出
+func setTags(stmt *Statement, tags map[string]string) {
+	if len(tags) > 0 {
+		stmt.Settings.Store(tagsSettingKey, tags)
+	}
+}
+
+// addTag 设置构建器的标签，返回新的 map，不修改 Clone 前共享的 map
+func addTag(tags map[string]string, key, value string) map[string]string {
+	tags = maps.Clone(tags)
+	if tags == nil {
+		tags = make(map[string]string, 1)
+	}
+	tags[key] = value
+	return tags
+}
+
+// tagStatement 注册了 Commenter 时在语句末尾追加注释
+// SQL 已经构建时直接追加，否则作为最后一个子句交给 gorm 回调构建
+func tagStatement(tx *GormDB, op Operation) {
+	if tx == nil || tx.Config == nil {
+		return
+	}
+	c, ok := tx.Config.Plugins[commenterName].(*Commenter)
+	if !ok {
+		return
+	}
+	stmt := tx.Statement
+	var builderTags map[string]string
+	if v, ok := stmt.Settings.Load(tagsSettingKey); ok {
+		builderTags, _ = v.(map[string]string)
+	}
+	comment := c.comment(stmt.Context, builderTags)
+	if comment == "" {
+		return
+	}
+	if stmt.SQL.Len() > 0 {
+		stmt.SQL.WriteByte(' ')
+		stmt.SQL.WriteString(comment)
+		return
+	}
+
+	clauses := operationClauses(tx, op)
+	stmt.Clauses[commentClauseName] = clause.Clause{Expression: clause.Expr{SQL: comment}}
+	stmt.BuildClauses = append(slices.Clone(clauses), commentClauseName)
+}
+
+// operationClauses 返回 op 对应语句要构建的子句，Statement 已指定 BuildClauses 时使用 BuildClauses
+func operationClauses(tx *GormDB, op Operation) []string {
+	if len(tx.Statement.BuildClauses) > 0 {
+		return tx.Statement.BuildClauses
+	}
+	switch op {
+	case OpSelect:
+		return tx.Callback().Query().Clauses
+	case OpInsert, OpUpsert, OpReplace:
+		return tx.Callback().Create().Clauses
+	case OpUpdate:
+		return tx.Callback().Update().Clauses
+	case OpDelete:
+		return tx.Callback().Delete().Clauses
+	}
+	return nil
+}
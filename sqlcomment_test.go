@@ -0,0 +1,103 @@
+package gsql_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+)
+
+func TestFormatComment(t *testing.T) {
+	got := gsql.FormatComment(map[string]string{"route": "/orders", "app": "svc", "x": "it's */ bad"})
+	expect := "/*app='svc',route='%2Forders',x='it%27s%20%2A%2F%20bad'*/"
+	if got != expect {
+		t.Errorf("期望 %s，实际: %s", expect, got)
+	}
+	if gsql.FormatComment(nil) != "" {
+		t.Error("没有标签时应返回空字符串")
+	}
+}
+
+func TestCommenter(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	rows := []MessageConsumerProgress{{ID: 1, ConsumerGroup: "g"}}
+	ctx := gsql.WithTags(context.Background(), "route", "/orders", "action", "ctx")
+
+	t.Run("未注册时不追加注释", func(t *testing.T) {
+		db, pool := openCaptureDB(t, "8.0.36")
+		_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).WithContext(ctx).Tag("action", "list").Find(db)
+		if len(pool.sqls) != 1 || strings.Contains(pool.sqls[0], "/*") {
+			t.Errorf("不应追加注释: %v", pool.sqls)
+		}
+	})
+
+	db, pool := openCaptureDB(t, "8.0.36")
+	if err := db.Use(gsql.NewCommenter(
+		gsql.WithStaticTags(map[string]string{"app": "svc"}),
+		gsql.WithContextTagger(func(ctx context.Context) map[string]string {
+			return map[string]string{"traceparent": "00-abc-01"}
+		}),
+	)); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+
+	_, _ = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).WithContext(ctx).Tag("action", "list").Find(db)
+	_ = gsql.Select().From(table).Where(table.ID.Eq(1)).Tag("action", "update").Update(db, map[string]any{"generation_id": 2})
+	_ = gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).Tag("action", "delete").Delete(db)
+	_ = gsql.InsertInto(table).Values(&rows).WithContext(ctx).Exec(db)
+	_ = gsql.InsertInto(table).Values(&rows).DuplicateUpdate(table.GenerationID).Tag("action", "upsert").Exec(db)
+	_ = gsql.ReplaceInto(table).Values(&rows).Tag("action", "replace").Exec(db)
+
+	expect := []struct{ prefix, comment string }{
+		{"SELECT", "/*action='list',app='svc',route='%2Forders',traceparent='00-abc-01'*/"},
+		{"UPDATE", "/*action='update',app='svc',traceparent='00-abc-01'*/"},
+		{"DELETE", "/*action='delete',app='svc',traceparent='00-abc-01'*/"},
+		{"INSERT", "/*action='ctx',app='svc',route='%2Forders',traceparent='00-abc-01'*/"},
+		{"INSERT", "/*action='upsert',app='svc',traceparent='00-abc-01'*/"},
+		{"REPLACE", "/*action='replace',app='svc',traceparent='00-abc-01'*/"},
+	}
+	if len(pool.sqls) != len(expect) {
+		t.Fatalf("期望 %d 条 SQL，实际: %v", len(expect), pool.sqls)
+	}
+	for i, e := range expect {
+		sql := pool.sqls[i]
+		if !strings.HasPrefix(sql, e.prefix) || !strings.HasSuffix(sql, " "+e.comment) {
+			t.Errorf("#%d 期望 %s ... %s，实际: %s", i, e.prefix, e.comment, sql)
+		}
+	}
+	if gsql.FingerprintSQL(pool.sqls[0]) != gsql.SelectG[MessageConsumerProgress]().From(table).Where(table.ID.Eq(1)).Fingerprint() {
+		t.Errorf("指纹不应包含注释: %s", gsql.FingerprintSQL(pool.sqls[0]))
+	}
+
+	// 同一构建器重复执行不应重复追加
+	q := gsql.SelectG[MessageConsumerProgress]().From(table).Tag("action", "again")
+	_, _ = q.Find(db)
+	_, _ = q.Find(db)
+	if n := len(pool.sqls); strings.Count(pool.sqls[n-1], "/*") != 1 || pool.sqls[n-1] != pool.sqls[n-2] {
+		t.Errorf("重复执行的 SQL 不一致: %s / %s", pool.sqls[n-2], pool.sqls[n-1])
+	}
+}
+
+func TestCommenter_CloneKeepsTags(t *testing.T) {
+	table := NewMessageConsumerProgressTable()
+	db, pool := openCaptureDB(t, "8.0.36")
+	if err := db.Use(gsql.NewCommenter()); err != nil {
+		t.Fatalf("use: %v", err)
+	}
+
+	q := gsql.Select().From(table).Tag("action", "list")
+	clone := q.Clone().Tag("page", "2")
+	var rows []MessageConsumerProgress
+	_ = q.Find(db, &rows)
+	_ = clone.Find(db, &rows)
+	if len(pool.sqls) != 2 {
+		t.Fatalf("期望 2 条 SQL，实际: %v", pool.sqls)
+	}
+	if !strings.HasSuffix(pool.sqls[0], " /*action='list'*/") {
+		t.Errorf("原构建器不应受 Clone 后的 Tag 影响: %s", pool.sqls[0])
+	}
+	if !strings.HasSuffix(pool.sqls[1], " /*action='list',page='2'*/") {
+		t.Errorf("Clone 应保留标签: %s", pool.sqls[1])
+	}
+}
@@ -0,0 +1,9 @@
+package tutorial
+
+import "github.com/donutnomad/gsql"
+
+// Active 返回 is_active 列的 BoolField，可以直接作为条件使用，如 Where(EmployeeSchema.Active())
+// generate.go 由 gormgen 生成，其中 bool 列仍为 IntField[bool]
+func (t EmployeeSchemaType) Active() gsql.BoolField[bool] {
+	return gsql.BoolFieldOf[bool](t.IsActive.TableName(), t.IsActive.ColumnName())
+}
@@ -0,0 +1,188 @@
+package gsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// MySQL 可重试的错误码
+const (
+	ErrCodeLockWaitTimeout uint16 = 1205 // ER_LOCK_WAIT_TIMEOUT
+	ErrCodeDeadlock        uint16 = 1213 // ER_LOCK_DEADLOCK
+)
+
+// TxOptions Tx 的事务与重试配置，零值字段使用 DefaultTxOptions 中的值
+type TxOptions struct {
+	// MaxAttempts 最大尝试次数（包含第一次执行）
+	MaxAttempts int
+	// BaseDelay 第一次重试前的等待时间，之后每次翻倍
+	BaseDelay time.Duration
+	// MaxDelay 单次等待时间的上限
+	MaxDelay time.Duration
+	// Jitter 等待时间的随机抖动比例，取值 (0, 1]，如 0.2 表示在 ±20% 范围内浮动；小于 0 时不抖动
+	Jitter float64
+	// Retryable 判断错误是否可重试，默认为 IsRetryableError
+	Retryable func(err error) bool
+	// TxOptions 开启事务时使用的隔离级别等选项
+	TxOptions *sql.TxOptions
+}
+
+// DefaultTxOptions Tx 的默认配置
+var DefaultTxOptions = TxOptions{
+	MaxAttempts: 3,
+	BaseDelay:   20 * time.Millisecond,
+	MaxDelay:    time.Second,
+	Jitter:      0.2,
+	Retryable:   IsRetryableError,
+}
+
+// ErrNestedTxOptions 在事务内嵌套调用 Tx 时指定了 TxOptions.TxOptions，SAVEPOINT 无法更改隔离级别等事务选项
+var ErrNestedTxOptions = errors.New("nested transaction cannot change sql.TxOptions")
+
+// TxAttempt 一次事务尝试的记录
+type TxAttempt struct {
+	Err      error         // 本次尝试返回的错误
+	Duration time.Duration // 本次尝试的耗时
+	Delay    time.Duration // 本次失败后重试前的等待时间，最后一次为 0
+}
+
+// TxError 事务经过多次尝试仍然失败时返回，Unwrap 返回最后一次的错误
+type TxError struct {
+	Attempts []TxAttempt
+}
+
+func (e *TxError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "transaction failed after %d attempts: %v", len(e.Attempts), e.Err())
+	for i, a := range e.Attempts[:len(e.Attempts)-1] {
+		fmt.Fprintf(&sb, "; attempt %d: %v", i+1, a.Err)
+	}
+	return sb.String()
+}
+
+// Err 返回最后一次尝试的错误
+func (e *TxError) Err() error {
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Err()
+}
+
+// IsRetryableError 判断错误是否为 MySQL 死锁（1213）或锁等待超时（1205）
+func IsRetryableError(err error) bool {
+	var myErr *mysql.MySQLError
+	if !errors.As(err, &myErr) {
+		return false
+	}
+	return myErr.Number == ErrCodeDeadlock || myErr.Number == ErrCodeLockWaitTimeout
+}
+
+// Tx 在事务中执行 fn，遇到死锁或锁等待超时时回滚并按指数退避重试整个事务
+// opts 为 nil 时使用 DefaultTxOptions，ctx 为 nil 时使用 context.Background()
+//   - fn 可能被执行多次，不应包含事务外的副作用
+//   - 在事务内嵌套调用 Tx 时使用 SAVEPOINT，出错只回滚到保存点且不重试，由最外层事务负责重试
+//     此时 opts 中的重试配置被忽略；指定 TxOptions.TxOptions 时返回 ErrNestedTxOptions
+//   - 重试过至少一次后仍失败时返回 *TxError，可用 errors.Is/As 判断最后一次的错误
+//
+// 示例:
+//
+//	err := gsql.Tx(ctx, db, nil, func(tx gsql.IDB) error {
+//		if err := gsql.InsertInto(t).Value(row).Exec(tx.Session(&gsql.Session{})); err != nil {
+//			return err
+//		}
+//		return gsql.Tx(ctx, tx, nil, func(tx gsql.IDB) error { ... }) // SAVEPOINT
+//	})
+func Tx(ctx context.Context, db IDB, opts *TxOptions, fn func(tx IDB) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var o TxOptions
+	if opts != nil {
+		o = *opts
+	}
+	o = o.withDefaults()
+	gdb := db.Session(&gorm.Session{Context: ctx})
+	txFn := func(tx *gorm.DB) error { return fn(tx) }
+
+	// 已在事务中：gorm 使用 SAVEPOINT 实现嵌套事务
+	if committer, ok := gdb.Statement.ConnPool.(gorm.TxCommitter); ok && committer != nil {
+		if o.TxOptions != nil {
+			return ErrNestedTxOptions
+		}
+		return gdb.Transaction(txFn)
+	}
+
+	var attempts []TxAttempt
+	for {
+		start := time.Now()
+		err := gdb.Transaction(txFn, o.TxOptions)
+		if err == nil {
+			return nil
+		}
+		attempts = append(attempts, TxAttempt{Err: err, Duration: time.Since(start)})
+		if len(attempts) >= o.MaxAttempts || !o.Retryable(err) {
+			break
+		}
+		delay := o.backoff(len(attempts))
+		attempts[len(attempts)-1].Delay = delay
+		if err := sleepContext(ctx, delay); err != nil {
+			return fmt.Errorf("transaction retry aborted: %w: %w", err, &TxError{Attempts: attempts})
+		}
+	}
+	if len(attempts) == 1 {
+		return attempts[0].Err
+	}
+	return &TxError{Attempts: attempts}
+}
+
+func (o TxOptions) withDefaults() TxOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultTxOptions.MaxAttempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = DefaultTxOptions.BaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = DefaultTxOptions.MaxDelay
+	}
+	if o.Jitter == 0 {
+		o.Jitter = DefaultTxOptions.Jitter
+	}
+	o.Jitter = min(max(o.Jitter, 0), 1)
+	if o.Retryable == nil {
+		o.Retryable = DefaultTxOptions.Retryable
+	}
+	return o
+}
+
+// backoff 返回第 n 次失败后的等待时间：BaseDelay * 2^(n-1)，不超过 MaxDelay，再叠加随机抖动
+func (o TxOptions) backoff(n int) time.Duration {
+	delay := o.MaxDelay
+	if shift := n - 1; shift < 32 && o.BaseDelay<<shift > 0 {
+		delay = min(o.BaseDelay<<shift, o.MaxDelay)
+	}
+	if o.Jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + o.Jitter*(2*rand.Float64()-1)))
+	}
+	return delay
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
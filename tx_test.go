@@ -0,0 +1,208 @@
+package gsql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/donutnomad/gsql"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// txCapturePool 在 capturePool 的基础上支持开启事务，记录 BEGIN/COMMIT/ROLLBACK
+type txCapturePool struct {
+	*capturePool
+}
+
+func (p *txCapturePool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.sqls = append(p.sqls, "BEGIN")
+	return &captureTx{p.capturePool}, nil
+}
+
+type captureTx struct {
+	*capturePool
+}
+
+func (t *captureTx) Commit() error {
+	t.sqls = append(t.sqls, "COMMIT")
+	return nil
+}
+
+func (t *captureTx) Rollback() error {
+	t.sqls = append(t.sqls, "ROLLBACK")
+	return nil
+}
+
+func openTxCaptureDB(t *testing.T) (*gorm.DB, *capturePool) {
+	t.Helper()
+	pool := &capturePool{}
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      &txCapturePool{pool},
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	return db, pool
+}
+
+var fastRetry = &gsql.TxOptions{MaxAttempts: 3, BaseDelay: time.Microsecond, MaxDelay: time.Millisecond}
+
+func TestTx_RetryDeadlock(t *testing.T) {
+	db, pool := openTxCaptureDB(t)
+
+	calls := 0
+	err := gsql.Tx(context.Background(), db, fastRetry, func(tx gsql.IDB) error {
+		calls++
+		if calls < 3 {
+			return &mysqldriver.MySQLError{Number: gsql.ErrCodeDeadlock, Message: "Deadlock found"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("tx: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("期望执行 3 次，实际: %d", calls)
+	}
+	expect := "BEGIN,ROLLBACK,BEGIN,ROLLBACK,BEGIN,COMMIT"
+	if got := strings.Join(pool.sqls, ","); got != expect {
+		t.Errorf("期望 %s，实际: %s", expect, got)
+	}
+}
+
+func TestTx_NilContext(t *testing.T) {
+	db, _ := openTxCaptureDB(t)
+
+	calls := 0
+	err := gsql.Tx(nil, db, fastRetry, func(tx gsql.IDB) error {
+		calls++
+		if calls < 2 {
+			return &mysqldriver.MySQLError{Number: gsql.ErrCodeDeadlock, Message: "Deadlock found"}
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("nil context 应使用 context.Background() 重试，实际执行 %d 次: %v", calls, err)
+	}
+}
+
+func TestTx_AttemptsExhausted(t *testing.T) {
+	db, _ := openTxCaptureDB(t)
+
+	calls := 0
+	err := gsql.Tx(context.Background(), db, fastRetry, func(tx gsql.IDB) error {
+		calls++
+		return &mysqldriver.MySQLError{Number: gsql.ErrCodeLockWaitTimeout, Message: "Lock wait timeout exceeded"}
+	})
+	var txErr *gsql.TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("期望 *TxError，实际: %v", err)
+	}
+	if calls != 3 || len(txErr.Attempts) != 3 {
+		t.Errorf("期望 3 次尝试，实际执行 %d 次，记录 %d 次", calls, len(txErr.Attempts))
+	}
+	for i, a := range txErr.Attempts[:2] {
+		if a.Delay <= 0 {
+			t.Errorf("第 %d 次尝试应记录重试等待时间", i+1)
+		}
+	}
+	var myErr *mysqldriver.MySQLError
+	if !errors.As(err, &myErr) || myErr.Number != gsql.ErrCodeLockWaitTimeout {
+		t.Errorf("期望可以取出最后一次的 MySQLError，实际: %v", err)
+	}
+}
+
+func TestTx_NonRetryable(t *testing.T) {
+	db, _ := openTxCaptureDB(t)
+
+	calls := 0
+	err := gsql.Tx(context.Background(), db, fastRetry, func(tx gsql.IDB) error {
+		calls++
+		return gsql.ErrRecordNotFound
+	})
+	if err != gsql.ErrRecordNotFound {
+		t.Errorf("不可重试的错误应原样返回，实际: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("期望执行 1 次，实际: %d", calls)
+	}
+}
+
+func TestTx_NestedSavepoint(t *testing.T) {
+	db, pool := openTxCaptureDB(t)
+	ctx := context.Background()
+
+	outer := 0
+	err := gsql.Tx(ctx, db, fastRetry, func(tx gsql.IDB) error {
+		outer++
+		inner := 0
+		err := gsql.Tx(ctx, tx, fastRetry, func(tx gsql.IDB) error {
+			inner++
+			return &mysqldriver.MySQLError{Number: gsql.ErrCodeDeadlock}
+		})
+		if inner != 1 {
+			t.Errorf("嵌套事务不应重试，实际执行 %d 次", inner)
+		}
+		if outer == 1 {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("tx: %v", err)
+	}
+	if outer != 2 {
+		t.Errorf("最外层事务应重试，实际执行 %d 次", outer)
+	}
+	got := strings.Join(pool.sqls, ",")
+	if !strings.HasPrefix(got, "BEGIN,SAVEPOINT sp") || !strings.Contains(got, "ROLLBACK TO SAVEPOINT sp") || !strings.HasSuffix(got, "COMMIT") {
+		t.Errorf("期望使用 SAVEPOINT 实现嵌套事务，实际: %s", got)
+	}
+}
+
+func TestTx_ContextCanceled(t *testing.T) {
+	db, _ := openTxCaptureDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := gsql.Tx(ctx, db, &gsql.TxOptions{BaseDelay: time.Hour, MaxDelay: time.Hour}, func(tx gsql.IDB) error {
+		cancel()
+		return &mysqldriver.MySQLError{Number: gsql.ErrCodeDeadlock}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("期望 context.Canceled，实际: %v", err)
+	}
+	var txErr *gsql.TxError
+	if !errors.As(err, &txErr) || len(txErr.Attempts) != 1 {
+		t.Errorf("期望保留尝试记录，实际: %v", err)
+	}
+}
+
+func TestTx_NestedTxOptions(t *testing.T) {
+	db, pool := openTxCaptureDB(t)
+	ctx := context.Background()
+
+	err := gsql.Tx(ctx, db, nil, func(tx gsql.IDB) error {
+		called := false
+		err := gsql.Tx(ctx, tx, &gsql.TxOptions{TxOptions: &sql.TxOptions{Isolation: sql.LevelSerializable}}, func(tx gsql.IDB) error {
+			called = true
+			return nil
+		})
+		if called {
+			t.Error("指定隔离级别的嵌套事务不应执行")
+		}
+		return err
+	})
+	if !errors.Is(err, gsql.ErrNestedTxOptions) {
+		t.Fatalf("期望 ErrNestedTxOptions，实际: %v", err)
+	}
+	if got := strings.Join(pool.sqls, ","); got != "BEGIN,ROLLBACK" {
+		t.Errorf("期望外层事务回滚，实际: %s", got)
+	}
+}
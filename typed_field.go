@@ -18,10 +18,43 @@ func NewLitExpr[T any](value T) *fields.LitExpr {
 	return fields.NewLitExpr[T](value)
 }
 
+// NewPoint 创建 WGS 84（SRID 4326）坐标系下的点
+func NewPoint(lat, lng float64) fields.Point {
+	return fields.NewPoint(lat, lng)
+}
+
+// Bool creates a BoolExpr[bool] from a clause expression.
+func Bool(expr clause.Expression) fields.BoolExpr[bool] {
+	return fields.Bool(expr)
+}
+
+// BoolColumn 返回 ScalarField[bool] 的列构造器，BoolField 使用 BoolFieldColumn
 func BoolColumn(name string) fields.ScalarColumnBuilder[bool] {
 	return fields.BoolColumn(name)
 }
 
+func BoolFieldColumn[T any](name string) fields.BoolFieldColumnBuilder[T] {
+	return fields.BoolFieldColumn[T](name)
+}
+
+func BoolFieldOf[T any](tableName, name string, flags ...types.FieldFlag) fields.BoolField[T] {
+	return fields.BoolFieldOf[T](tableName, name, flags...)
+}
+
+func BoolFrom[T any](field interface{ FieldType() T }) fields.BoolExpr[T] {
+	return fields.BoolFrom[T](field)
+}
+
+// BoolOf creates a generic BoolExpr[T] from a clause expression.
+func BoolOf[T any](expr clause.Expression) fields.BoolExpr[T] {
+	return fields.BoolOf[T](expr)
+}
+
+// BoolVal creates a BoolExpr from a bool literal value.
+func BoolVal[T ~bool | any](val T) fields.BoolExpr[T] {
+	return fields.BoolVal[T](val)
+}
+
 func CastExpr[Expr interface{ ExprType() R }, R any](v clause.Expression) Expr {
 	return fields.CastExpr[Expr, R](v)
 }
@@ -126,6 +159,24 @@ func DecimalVal[T ~float32 | ~float64 | any](val T) fields.DecimalExpr[T] {
 	return fields.DecimalVal[T](val)
 }
 
+// EnumCase 将枚举值映射为任意类型的值，生成 CASE col WHEN ... THEN ... END
+// 按枚举声明顺序输出 WHEN 分支，m 中没有的取值返回 elseValue（省略时为 NULL）
+// 示例:
+//
+//	gsql.EnumCase(o.Status, map[OrderStatus]int{OrderPending: 1, OrderPaid: 2}, 0)
+//	// CASE `orders`.`status` WHEN 'pending' THEN 1 WHEN 'paid' THEN 2 ELSE 0 END
+func EnumCase[T EnumValue[T], R any](f EnumField[T], m map[T]R, elseValue ...R) fields.ScalarExpr[R] {
+	return fields.EnumCase[T, R](f, m, elseValue...)
+}
+
+func EnumColumn[T EnumValue[T]](name string) fields.EnumColumnBuilder[T] {
+	return fields.EnumColumn[T](name)
+}
+
+func EnumFieldOf[T EnumValue[T]](tableName, name string, flags ...types.FieldFlag) fields.EnumField[T] {
+	return fields.EnumFieldOf[T](tableName, name, flags...)
+}
+
 // Float creates a FloatExpr[float64] from a clause expression.
 func Float(expr clause.Expression) fields.FloatExpr[float64] {
 	return fields.Float(expr)
@@ -158,6 +209,36 @@ func FloatVal[T ~float32 | ~float64 | any](val T) fields.FloatExpr[T] {
 	return fields.FloatVal[T](val)
 }
 
+// GeomFromText 从 WKT 创建几何值 (ST_GeomFromText)，坐标按经度在前、纬度在后解析
+// srid 省略时为 0（笛卡尔坐标系）
+// SELECT ST_GeomFromText('POINT(121.4737 31.2304)', 4326, 'axis-order=long-lat');
+// 示例: gsql.GeomFromText("POLYGON((0 0,10 0,10 10,0 10,0 0))", gsql.SRIDWGS84)
+func GeomFromText(wkt string, srid ...uint32) fields.GeometryExpr[[]byte] {
+	return fields.GeomFromText(wkt, srid...)
+}
+
+// GeomFromWKB 从 WKB 创建几何值 (ST_GeomFromWKB)，坐标按经度在前、纬度在后解析
+// SELECT ST_GeomFromWKB(x'0101000000...', 4326, 'axis-order=long-lat');
+func GeomFromWKB(wkb []byte, srid ...uint32) fields.GeometryExpr[[]byte] {
+	return fields.GeomFromWKB(wkb, srid...)
+}
+
+func GeometryColumn[T any](name string) fields.GeometryColumnBuilder[T] {
+	return fields.GeometryColumn[T](name)
+}
+
+func GeometryFieldOf[T any](tableName, name string, flags ...types.FieldFlag) fields.GeometryField[T] {
+	return fields.GeometryFieldOf[T](tableName, name, flags...)
+}
+
+func GeometryFrom[T any](field interface{ FieldType() T }) fields.GeometryExpr[T] {
+	return fields.GeometryFrom[T](field)
+}
+
+func GeometryOf[T any](expr clause.Expression) fields.GeometryExpr[T] {
+	return fields.GeometryOf[T](expr)
+}
+
 // Int creates an IntExpr[int64] from a clause expression.
 func Int(expr clause.Expression) fields.IntExpr[int64] {
 	return fields.Int(expr)
@@ -220,6 +301,13 @@ func JsonObjectAgg(key, value clause.Expression) fields.JsonExpr[string] {
 	return fields.JsonObjectAgg(key, value)
 }
 
+// LatLng 创建 WGS 84（SRID 4326）坐标系下的点 (ST_SRID(POINT(lng, lat), 4326))
+// SELECT ST_SRID(POINT(121.4737, 31.2304), 4326);
+// 示例: s.Location.DistanceSphere(gsql.LatLng(31.2304, 121.4737)).Lt(5000)
+func LatLng(lat, lng float64) fields.GeometryExpr[fields.Point] {
+	return fields.LatLng(lat, lng)
+}
+
 func ScalarColumn[T any](name string) fields.ScalarColumnBuilder[T] {
 	return fields.ScalarColumn[T](name)
 }
@@ -305,6 +393,21 @@ func TimeVal[T ~string | time.Time | *time.Time | sql.NullTime | any](val T) fie
 	return fields.TimeVal[T](val)
 }
 
+func UUIDColumn[T UUIDValue](name string) fields.UUIDColumnBuilder[T] {
+	return fields.UUIDColumn[T](name)
+}
+
+func UUIDFieldOf[T UUIDValue](tableName, name string, flags ...types.FieldFlag) fields.UUIDField[T] {
+	return fields.UUIDFieldOf[T](tableName, name, flags...)
+}
+
+// UUIDToBin 将 UUID 文本转换为 BINARY(16) (UUID_TO_BIN)，swap 为 true 时交换时间位
+// SELECT UUID_TO_BIN(UUID(), 1);
+// 数据库支持: MySQL 8.0+
+func UUIDToBin(expr clause.Expression, swap bool) fields.ScalarExpr[[]byte] {
+	return fields.UUIDToBin(expr, swap)
+}
+
 // Uint creates an IntExpr[uint64] from a clause expression.
 func Uint(expr clause.Expression) fields.IntExpr[uint64] {
 	return fields.Uint(expr)
@@ -337,39 +440,57 @@ func YearVal[T ~int | ~int16 | ~int32 | ~int64 | any](val T) fields.YearExpr[T]
 // ==================== Type Aliases ====================
 
 type (
-	BaseFields                   = fields.BaseFields
-	Condition                    = fields.Condition
-	DateColumnBuilder[T any]     = fields.DateColumnBuilder[T]
-	DateExpr[T any]              = fields.DateExpr[T]
-	DateField[T any]             = fields.DateField[T]
-	DateTimeColumnBuilder[T any] = fields.DateTimeColumnBuilder[T]
-	DateTimeExpr[T any]          = fields.DateTimeExpr[T]
-	DateTimeField[T any]         = fields.DateTimeField[T]
-	DecimalColumnBuilder[T any]  = fields.DecimalColumnBuilder[T]
-	DecimalExpr[T any]           = fields.DecimalExpr[T]
-	DecimalField[T any]          = fields.DecimalField[T]
-	Expressions[T any]           = fields.Expressions[T]
-	FloatColumnBuilder[T any]    = fields.FloatColumnBuilder[T]
-	FloatExpr[T any]             = fields.FloatExpr[T]
-	FloatField[T any]            = fields.FloatField[T]
-	FunctionName                 = fields.FunctionName
-	IntColumnBuilder[T any]      = fields.IntColumnBuilder[T]
-	IntConstraint                = fields.IntConstraint
-	IntExpr[T any]               = fields.IntExpr[T]
-	IntField[T any]              = fields.IntField[T]
-	JsonColumnBuilder[T any]     = fields.JsonColumnBuilder[T]
-	JsonExpr[T any]              = fields.JsonExpr[T]
-	JsonField[T any]             = fields.JsonField[T]
-	JsonInput                    = fields.JsonInput
-	LitExpr                      = fields.LitExpr
-	ScalarColumnBuilder[T any]   = fields.ScalarColumnBuilder[T]
-	ScalarExpr[T any]            = fields.ScalarExpr[T]
-	ScalarField[T any]           = fields.ScalarField[T]
-	StringColumnBuilder[T any]   = fields.StringColumnBuilder[T]
-	StringExpr[T any]            = fields.StringExpr[T]
-	StringField[T any]           = fields.StringField[T]
-	TimeColumnBuilder[T any]     = fields.TimeColumnBuilder[T]
-	TimeExpr[T any]              = fields.TimeExpr[T]
-	TimeField[T any]             = fields.TimeField[T]
-	YearExpr[T any]              = fields.YearExpr[T]
+	Assignment                        = fields.Assignment
+	BaseFields                        = fields.BaseFields
+	BinaryUUID                        = fields.BinaryUUID
+	BinaryUUIDNoSwap                  = fields.BinaryUUIDNoSwap
+	BoolExpr[T any]                   = fields.BoolExpr[T]
+	BoolField[T any]                  = fields.BoolField[T]
+	BoolFieldColumnBuilder[T any]     = fields.BoolFieldColumnBuilder[T]
+	Condition                         = fields.Condition
+	DateColumnBuilder[T any]          = fields.DateColumnBuilder[T]
+	DateExpr[T any]                   = fields.DateExpr[T]
+	DateField[T any]                  = fields.DateField[T]
+	DateTimeColumnBuilder[T any]      = fields.DateTimeColumnBuilder[T]
+	DateTimeExpr[T any]               = fields.DateTimeExpr[T]
+	DateTimeField[T any]              = fields.DateTimeField[T]
+	DecimalColumnBuilder[T any]       = fields.DecimalColumnBuilder[T]
+	DecimalExpr[T any]                = fields.DecimalExpr[T]
+	DecimalField[T any]               = fields.DecimalField[T]
+	EnumColumnBuilder[T EnumValue[T]] = fields.EnumColumnBuilder[T]
+	EnumField[T EnumValue[T]]         = fields.EnumField[T]
+	EnumValue[T any]                  = fields.EnumValue[T]
+	Expressions[T any]                = fields.Expressions[T]
+	FloatColumnBuilder[T any]         = fields.FloatColumnBuilder[T]
+	FloatExpr[T any]                  = fields.FloatExpr[T]
+	FloatField[T any]                 = fields.FloatField[T]
+	FunctionName                      = fields.FunctionName
+	GeometryColumnBuilder[T any]      = fields.GeometryColumnBuilder[T]
+	GeometryExpr[T any]               = fields.GeometryExpr[T]
+	GeometryField[T any]              = fields.GeometryField[T]
+	GeometryInput                     = fields.GeometryInput
+	IntColumnBuilder[T any]           = fields.IntColumnBuilder[T]
+	IntConstraint                     = fields.IntConstraint
+	IntExpr[T any]                    = fields.IntExpr[T]
+	IntField[T any]                   = fields.IntField[T]
+	JsonColumnBuilder[T any]          = fields.JsonColumnBuilder[T]
+	JsonExpr[T any]                   = fields.JsonExpr[T]
+	JsonField[T any]                  = fields.JsonField[T]
+	JsonInput                         = fields.JsonInput
+	LitExpr                           = fields.LitExpr
+	Point                             = fields.Point
+	Polygon                           = fields.Polygon
+	ScalarColumnBuilder[T any]        = fields.ScalarColumnBuilder[T]
+	ScalarExpr[T any]                 = fields.ScalarExpr[T]
+	ScalarField[T any]                = fields.ScalarField[T]
+	StringColumnBuilder[T any]        = fields.StringColumnBuilder[T]
+	StringExpr[T any]                 = fields.StringExpr[T]
+	StringField[T any]                = fields.StringField[T]
+	TimeColumnBuilder[T any]          = fields.TimeColumnBuilder[T]
+	TimeExpr[T any]                   = fields.TimeExpr[T]
+	TimeField[T any]                  = fields.TimeField[T]
+	UUIDColumnBuilder[T UUIDValue]    = fields.UUIDColumnBuilder[T]
+	UUIDField[T UUIDValue]            = fields.UUIDField[T]
+	UUIDValue                         = fields.UUIDValue
+	YearExpr[T any]                   = fields.YearExpr[T]
 )
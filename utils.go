@@ -23,6 +23,9 @@ func getQuoteFunc() func(field string) string {
 	}
 }
 
+// QueryCallbacks 在 Scan 执行查询后调用，不包含插入、更新和删除
+//
+// Deprecated: 使用 ExecHook 或 Metrics 插件，它们覆盖所有执行路径且只对注册的连接生效
 var QueryCallbacks []func(*gorm.DB)
 
 func Scan(
@@ -37,10 +40,15 @@ func Scan(
 		session.Logger = db.Logger.LogMode(logger.LogLevel(logLevel))
 	}
 	tx := db.Session(session)
+	// 替换 logger 之前开始记录参数对应的列
+	trackBoundColumns(tx)
 	config := *tx.Config
-	currentLogger, newLogger := config.Logger, logger.Recorder.New()
+	// 记录开始时间以及占位符形式的 SQL，日志输出时由 currentLogger 过滤参数
+	currentLogger, recorder := config.Logger, logger.Recorder.New()
+	newLogger := &execLogger{Interface: recorder, capture: true}
 	config.Logger = newLogger
 	tx.Config = &config
+	obs := beginExec(tx, OpSelect, tx.Statement.Table)
 
 	_ = tx.Statement.Parse(dest)
 
@@ -61,9 +69,15 @@ func Scan(
 	for _, fn := range QueryCallbacks {
 		fn(tx)
 	}
+	// 列表查询没有结果不是错误，只有 First/Take/Last（RaiseErrorOnNotFound）向 ExecHook 报告 ErrRecordNotFound
+	obsErr := tx.Error
+	if obsErr == gorm.ErrRecordNotFound && !tx.Statement.RaiseErrorOnNotFound {
+		obsErr = nil
+	}
+	obs.end(tx.Statement, tx.RowsAffected, obsErr)
 
-	currentLogger.Trace(tx.Statement.Context, newLogger.BeginAt, func() (string, int64) {
-		return newLogger.SQL, tx.RowsAffected
+	currentLogger.Trace(tx.Statement.Context, recorder.BeginAt, func() (string, int64) {
+		return explainSQL(tx.Statement.Context, currentLogger, tx.Dialector, newLogger.sql, newLogger.vars), tx.RowsAffected
 	}, tx.Error)
 	tx.Logger = currentLogger
 	return tx
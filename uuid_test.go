@@ -0,0 +1,142 @@
+package gsql_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/donutnomad/gsql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// testUUID 与 uuid.UUID 相同的底层类型
+type testUUID [16]byte
+
+var (
+	uuidA = testUUID{0x6c, 0xcd, 0x78, 0x0c, 0xba, 0xba, 0x10, 0x26, 0x95, 0x64, 0x5b, 0x8c, 0x65, 0x60, 0x24, 0xdb}
+	uuidB = testUUID{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff, 0x00}
+)
+
+func TestUUIDField(t *testing.T) {
+	id := gsql.UUIDFieldOf[testUUID]("users", "id")
+	legacy := gsql.UUIDFieldOf[testUUID]("users", "legacy_id").WithoutSwap()
+	// WithAlias、WithTable 保留 WithoutSwap
+	archived := legacy.WithTable(gsql.TN("archived_users")).WithAlias("a")
+
+	sql := gsql.Select(id.Text().As("id"), legacy.Text().As("legacy_id")).
+		From(gsql.TN("users")).
+		Where(id.In(uuidA, uuidB), legacy.Not(uuidA), id.In(), archived.Eq(uuidB)).
+		ToSQL()
+
+	for _, expect := range []string{
+		"BIN_TO_UUID(`users`.`id`, 1) AS `id`",
+		"BIN_TO_UUID(`users`.`legacy_id`, 0) AS `legacy_id`",
+		"`users`.`id` IN (UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1),UUID_TO_BIN('11223344-5566-7788-99aa-bbccddeeff00', 1))",
+		"`users`.`legacy_id` != UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 0)",
+		"`archived_users`.`legacy_id` = UUID_TO_BIN('11223344-5566-7788-99aa-bbccddeeff00', 0)",
+	} {
+		if !strings.Contains(sql, expect) {
+			t.Errorf("期望包含 %s，实际: %s", expect, sql)
+		}
+	}
+}
+
+func TestUUIDFieldSet(t *testing.T) {
+	id := gsql.UUIDFieldOf[testUUID]("users", "id")
+	token := gsql.UUIDFieldOf[testUUID]("users", "token")
+
+	db, pool := openCaptureDB(t, "8.0.36")
+	ret := gsql.Select().From(gsql.TN("users")).Where(id.Eq(uuidA)).Update(db, token.SetNew())
+	if ret.Error != nil {
+		t.Fatalf("update: %v", ret.Error)
+	}
+	if len(pool.sqls) != 1 || !strings.Contains(pool.sqls[0], "SET `token`=UUID_TO_BIN(UUID(), ?)") {
+		t.Errorf("期望 SET `token`=UUID_TO_BIN(UUID(), ?)，实际: %v", pool.sqls)
+	}
+}
+
+type uuidUser struct {
+	ID   gsql.BinaryUUID
+	Name string
+}
+
+func TestBinaryUUID(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      &capturePool{},
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	row := uuidUser{ID: gsql.BinaryUUID(uuidA), Name: "a"}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("users").Create(&row)
+	})
+	if expect := "UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1)"; !strings.Contains(sql, expect) {
+		t.Errorf("期望包含 %s，实际: %s", expect, sql)
+	}
+
+	// UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1) 的存储格式
+	stored := []byte{0x10, 0x26, 0xba, 0xba, 0x6c, 0xcd, 0x78, 0x0c, 0x95, 0x64, 0x5b, 0x8c, 0x65, 0x60, 0x24, 0xdb}
+	v, err := row.ID.Value()
+	if err != nil || !bytes.Equal(v.([]byte), stored) {
+		t.Errorf("期望 %x，实际: %x, %v", stored, v, err)
+	}
+
+	var u gsql.BinaryUUID
+	if err := u.Scan(stored); err != nil || u != gsql.BinaryUUID(uuidA) {
+		t.Errorf("扫描 BINARY(16) 失败: %s, %v", u, err)
+	}
+	if err := u.Scan("11223344-5566-7788-99aa-bbccddeeff00"); err != nil || u != gsql.BinaryUUID(uuidB) {
+		t.Errorf("扫描 BIN_TO_UUID 文本失败: %s, %v", u, err)
+	}
+	if err := u.Scan([]byte{1, 2, 3}); err == nil {
+		t.Error("期望长度错误时返回错误")
+	}
+}
+
+type uuidSession struct {
+	ID gsql.BinaryUUIDNoSwap
+}
+
+func TestBinaryUUIDNoSwap(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      &capturePool{},
+		ServerVersion:             "8.0.36",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	row := uuidSession{ID: gsql.BinaryUUIDNoSwap(uuidA)}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("sessions").Create(&row)
+	})
+	if expect := "UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 0)"; !strings.Contains(sql, expect) {
+		t.Errorf("期望包含 %s，实际: %s", expect, sql)
+	}
+	id := gsql.UUIDFieldOf[gsql.BinaryUUIDNoSwap]("sessions", "id").WithoutSwap()
+	if got := gsql.Select().From(gsql.TN("sessions")).Where(id.Eq(row.ID)).ToSQL(); !strings.Contains(got, "UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 0)") {
+		t.Errorf("WithoutSwap 与 BinaryUUIDNoSwap 的存储格式应一致，实际: %s", got)
+	}
+
+	// UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 0) 的存储格式
+	stored := []byte{0x6c, 0xcd, 0x78, 0x0c, 0xba, 0xba, 0x10, 0x26, 0x95, 0x64, 0x5b, 0x8c, 0x65, 0x60, 0x24, 0xdb}
+	v, err := row.ID.Value()
+	if err != nil || !bytes.Equal(v.([]byte), stored) {
+		t.Errorf("期望 %x，实际: %x, %v", stored, v, err)
+	}
+
+	var u gsql.BinaryUUIDNoSwap
+	if err := u.Scan(stored); err != nil || u != gsql.BinaryUUIDNoSwap(uuidA) {
+		t.Errorf("扫描 BINARY(16) 失败: %s, %v", u, err)
+	}
+	if err := u.Scan("11223344-5566-7788-99aa-bbccddeeff00"); err != nil || u != gsql.BinaryUUIDNoSwap(uuidB) {
+		t.Errorf("扫描 BIN_TO_UUID 文本失败: %s, %v", u, err)
+	}
+	if err := u.Scan(nil); err != nil || u != (gsql.BinaryUUIDNoSwap{}) {
+		t.Errorf("NULL 应扫描为零值，实际: %s, %v", u, err)
+	}
+}